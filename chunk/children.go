@@ -0,0 +1,184 @@
+package chunk
+
+import (
+  "github.com/jomei/notionapi"
+)
+
+// Children ... Nested children of a block, or nil for block types without children.
+func Children(block notionapi.Block) []notionapi.Block {
+  switch b := block.(type) {
+  case *notionapi.ParagraphBlock:
+    return b.Paragraph.Children
+  case notionapi.ParagraphBlock:
+    return b.Paragraph.Children
+  case *notionapi.Heading1Block:
+    return b.Heading1.Children
+  case notionapi.Heading1Block:
+    return b.Heading1.Children
+  case *notionapi.Heading2Block:
+    return b.Heading2.Children
+  case notionapi.Heading2Block:
+    return b.Heading2.Children
+  case *notionapi.Heading3Block:
+    return b.Heading3.Children
+  case notionapi.Heading3Block:
+    return b.Heading3.Children
+  case *notionapi.CalloutBlock:
+    return b.Callout.Children
+  case notionapi.CalloutBlock:
+    return b.Callout.Children
+  case *notionapi.QuoteBlock:
+    return b.Quote.Children
+  case notionapi.QuoteBlock:
+    return b.Quote.Children
+  case *notionapi.TableBlock:
+    return b.Table.Children
+  case notionapi.TableBlock:
+    return b.Table.Children
+  case *notionapi.BulletedListItemBlock:
+    return b.BulletedListItem.Children
+  case notionapi.BulletedListItemBlock:
+    return b.BulletedListItem.Children
+  case *notionapi.NumberedListItemBlock:
+    return b.NumberedListItem.Children
+  case notionapi.NumberedListItemBlock:
+    return b.NumberedListItem.Children
+  case *notionapi.ToDoBlock:
+    return b.ToDo.Children
+  case notionapi.ToDoBlock:
+    return b.ToDo.Children
+  case *notionapi.ToggleBlock:
+    return b.Toggle.Children
+  case notionapi.ToggleBlock:
+    return b.Toggle.Children
+  case *notionapi.ColumnListBlock:
+    return b.ColumnList.Children
+  case notionapi.ColumnListBlock:
+    return b.ColumnList.Children
+  case *notionapi.ColumnBlock:
+    return b.Column.Children
+  case notionapi.ColumnBlock:
+    return b.Column.Children
+  }
+  return nil
+}
+
+// WithChildren ... Copy of a block with its nested children replaced, leaving the original untouched.
+// Blocks of types without children are returned as they are.
+func WithChildren(block notionapi.Block, children []notionapi.Block) notionapi.Block {
+  switch b := block.(type) {
+  case *notionapi.ParagraphBlock:
+    c := *b
+    c.Paragraph.Children = children
+    return &c
+  case notionapi.ParagraphBlock:
+    b.Paragraph.Children = children
+    return b
+  case *notionapi.Heading1Block:
+    c := *b
+    c.Heading1.Children = children
+    return &c
+  case notionapi.Heading1Block:
+    b.Heading1.Children = children
+    return b
+  case *notionapi.Heading2Block:
+    c := *b
+    c.Heading2.Children = children
+    return &c
+  case notionapi.Heading2Block:
+    b.Heading2.Children = children
+    return b
+  case *notionapi.Heading3Block:
+    c := *b
+    c.Heading3.Children = children
+    return &c
+  case notionapi.Heading3Block:
+    b.Heading3.Children = children
+    return b
+  case *notionapi.CalloutBlock:
+    c := *b
+    c.Callout.Children = children
+    return &c
+  case notionapi.CalloutBlock:
+    b.Callout.Children = children
+    return b
+  case *notionapi.QuoteBlock:
+    c := *b
+    c.Quote.Children = children
+    return &c
+  case notionapi.QuoteBlock:
+    b.Quote.Children = children
+    return b
+  case *notionapi.TableBlock:
+    c := *b
+    c.Table.Children = children
+    return &c
+  case notionapi.TableBlock:
+    b.Table.Children = children
+    return b
+  case *notionapi.BulletedListItemBlock:
+    c := *b
+    c.BulletedListItem.Children = children
+    return &c
+  case notionapi.BulletedListItemBlock:
+    b.BulletedListItem.Children = children
+    return b
+  case *notionapi.NumberedListItemBlock:
+    c := *b
+    c.NumberedListItem.Children = children
+    return &c
+  case notionapi.NumberedListItemBlock:
+    b.NumberedListItem.Children = children
+    return b
+  case *notionapi.ToDoBlock:
+    c := *b
+    c.ToDo.Children = children
+    return &c
+  case notionapi.ToDoBlock:
+    b.ToDo.Children = children
+    return b
+  case *notionapi.ToggleBlock:
+    c := *b
+    c.Toggle.Children = children
+    return &c
+  case notionapi.ToggleBlock:
+    b.Toggle.Children = children
+    return b
+  case *notionapi.ColumnListBlock:
+    c := *b
+    c.ColumnList.Children = children
+    return &c
+  case notionapi.ColumnListBlock:
+    b.ColumnList.Children = children
+    return b
+  case *notionapi.ColumnBlock:
+    c := *b
+    c.Column.Children = children
+    return &c
+  case notionapi.ColumnBlock:
+    b.Column.Children = children
+    return b
+  }
+  return block
+}
+
+// DeferChildren ... Split a block into the part sent in one append request and the children appended to it afterwards.
+// Notion accepts two levels of nesting per request, so a block keeps its children only if none of them has
+// children of its own, and at most BlockLimit of them. A table keeps its first BlockLimit rows.
+func DeferChildren(block notionapi.Block) (notionapi.Block, []notionapi.Block) {
+  children := Children(block)
+  if len(children) == 0 {
+    return block, nil
+  }
+
+  for _, child := range children {
+    if len(Children(child)) > 0 {
+      return WithChildren(block, nil), children
+    }
+  }
+
+  if len(children) <= BlockLimit {
+    return block, nil
+  }
+  return WithChildren(block, children[:BlockLimit]), children[BlockLimit:]
+}
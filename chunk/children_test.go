@@ -0,0 +1,78 @@
+package chunk
+
+import (
+  "testing"
+
+  "github.com/jomei/notionapi"
+  "github.com/stretchr/testify/assert"
+)
+
+// newBulletedItem ... Create a bulleted list item with the given children
+func newBulletedItem(children ...notionapi.Block) *notionapi.BulletedListItemBlock {
+  return &notionapi.BulletedListItemBlock{
+    BasicBlock:       notionapi.BasicBlock{Object: notionapi.ObjectTypeBlock, Type: notionapi.BlockTypeBulletedListItem},
+    BulletedListItem: notionapi.ListItem{RichText: newRichTexts(1, "item"), Children: children},
+  }
+}
+
+func TestWithChildren(t *testing.T) {
+  t.Run("replaces children of a copy", func(t *testing.T) {
+    block := newBulletedItem(&notionapi.DividerBlock{})
+
+    result := WithChildren(block, nil)
+
+    assert.Empty(t, Children(result))
+    assert.Len(t, Children(block), 1)
+  })
+
+  t.Run("keeps value blocks as values", func(t *testing.T) {
+    block := notionapi.ToggleBlock{}
+
+    result := WithChildren(block, []notionapi.Block{&notionapi.DividerBlock{}})
+
+    assert.IsType(t, notionapi.ToggleBlock{}, result)
+    assert.Len(t, Children(result), 1)
+  })
+
+  t.Run("returns blocks without children as they are", func(t *testing.T) {
+    block := &notionapi.DividerBlock{}
+
+    assert.Same(t, block, WithChildren(block, []notionapi.Block{&notionapi.DividerBlock{}}))
+  })
+}
+
+func TestDeferChildren(t *testing.T) {
+  t.Run("keeps children without nesting", func(t *testing.T) {
+    block := newBulletedItem(newBulletedItem(), newBulletedItem())
+
+    parent, deferred := DeferChildren(block)
+
+    assert.Same(t, block, parent)
+    assert.Empty(t, deferred)
+  })
+
+  t.Run("defers children with nested children", func(t *testing.T) {
+    grandchild := newBulletedItem()
+    children := []notionapi.Block{newBulletedItem(), newBulletedItem(grandchild)}
+    block := newBulletedItem(children...)
+
+    parent, deferred := DeferChildren(block)
+
+    assert.Empty(t, Children(parent))
+    assert.Equal(t, children, deferred)
+    assert.Equal(t, children, Children(block))
+  })
+
+  t.Run("defers table rows over the limit", func(t *testing.T) {
+    rows := make([]notionapi.Block, BlockLimit+20)
+    for i := range rows {
+      rows[i] = &notionapi.TableRowBlock{}
+    }
+    block := &notionapi.TableBlock{Table: notionapi.Table{TableWidth: 1, Children: rows}}
+
+    parent, deferred := DeferChildren(block)
+
+    assert.Equal(t, rows[:BlockLimit], Children(parent))
+    assert.Equal(t, rows[BlockLimit:], deferred)
+  })
+}
@@ -1,6 +1,12 @@
 package chunk
 
 import (
+  "encoding/json"
+  "strings"
+  "unicode"
+  "unicode/utf16"
+  "unicode/utf8"
+
   "github.com/jomei/notionapi"
 )
 
@@ -10,58 +16,80 @@ const (
 
   // CharacterLimit ... maximum number of characters that can be sent in a single rich text block.
   CharacterLimit = 2000
+
+  // DescendantLimit ... maximum number of blocks, counting nested children, that can be sent in a single request.
+  DescendantLimit = 1000
+
+  // PayloadLimit ... maximum size in bytes of a request body.
+  PayloadLimit = 500 * 1000
+
+  // requestOverhead ... size of the request body around the blocks, {"children":[]}.
+  requestOverhead = len(`{"children":[]}`)
 )
 
-// Blocks ... Split blocks into chunks of BlockLimit size.
+// Blocks ... Split blocks into batches that fit in a single request.
+// A batch has at most BlockLimit blocks, DescendantLimit blocks counting nested children
+// and PayloadLimit bytes of JSON. A block over the limits by itself makes up a batch alone.
 func Blocks(blocks []notionapi.Block) [][]notionapi.Block {
-  var chunks [][]notionapi.Block
-  chunkSize := BlockLimit
+  var batches [][]notionapi.Block
+  var batch []notionapi.Block
+  size, count := requestOverhead, 0
+
+  for _, block := range blocks {
+    blockSize, blockCount := payloadSize(block), countBlocks(block)
 
-  for i := 0; i < len(blocks); i += chunkSize {
-    end := i + chunkSize
-    if end > len(blocks) {
-      end = len(blocks)
+    if len(batch) > 0 && (len(batch) == BlockLimit || count+blockCount > DescendantLimit || size+blockSize > PayloadLimit) {
+      batches = append(batches, batch)
+      batch = nil
+      size, count = requestOverhead, 0
     }
-    chunks = append(chunks, blocks[i:end])
+
+    batch = append(batch, block)
+    size += blockSize
+    count += blockCount
+  }
+
+  if len(batch) > 0 {
+    batches = append(batches, batch)
   }
 
-  return chunks
+  return batches
+}
+
+// payloadSize ... Size of a block in a request body, including the comma separating it from the next one.
+func payloadSize(block notionapi.Block) int {
+  data, err := json.Marshal(block)
+  if err != nil {
+    // The request fails to encode the block anyway, so its size doesn't matter
+    return 0
+  }
+  return len(data) + 1
+}
+
+// countBlocks ... Number of blocks in a block and its nested children.
+func countBlocks(block notionapi.Block) int {
+  count := 1
+  for _, child := range Children(block) {
+    count += countBlocks(child)
+  }
+  return count
 }
 
 // RichText ... Split rich text into chunks of CharacterLimit size.
 func RichText(content string, annotations *notionapi.Annotations) []notionapi.RichText {
   var blocks []notionapi.RichText
 
-  if len(content) <= CharacterLimit {
+  for _, chunk := range splitContent(content, CharacterLimit) {
     richText := notionapi.RichText{
       Type: notionapi.ObjectTypeText,
       Text: &notionapi.Text{
-        Content: content,
+        Content: chunk,
       },
-      PlainText:   content,
+      PlainText:   chunk,
       Annotations: annotations,
     }
 
     blocks = append(blocks, richText)
-  } else {
-    for i := 0; i < len(content); i += CharacterLimit {
-      end := i + CharacterLimit
-      if end > len(content) {
-        end = len(content)
-      }
-
-      chunk := content[i:end]
-      richText := notionapi.RichText{
-        Type: notionapi.ObjectTypeText,
-        Text: &notionapi.Text{
-          Content: chunk,
-        },
-        PlainText:   chunk,
-        Annotations: annotations,
-      }
-
-      blocks = append(blocks, richText)
-    }
   }
 
   return blocks
@@ -71,41 +99,82 @@ func RichText(content string, annotations *notionapi.Annotations) []notionapi.Ri
 func RichTextWithLink(content string, link string) []notionapi.RichText {
   var blocks []notionapi.RichText
 
-  if len(content) <= CharacterLimit {
+  for _, chunk := range splitContent(content, CharacterLimit) {
     richText := notionapi.RichText{
       Type: notionapi.ObjectTypeText,
       Text: &notionapi.Text{
-        Content: content,
+        Content: chunk,
         Link: &notionapi.Link{
           Url: link,
         },
       },
-      PlainText: content,
+      PlainText: chunk,
     }
 
     blocks = append(blocks, richText)
-  } else {
-    for i := 0; i < len(content); i += CharacterLimit {
-      end := i + CharacterLimit
-      if end > len(content) {
-        end = len(content)
-      }
-
-      chunk := content[i:end]
-      richText := notionapi.RichText{
-        Type: notionapi.ObjectTypeText,
-        Text: &notionapi.Text{
-          Content: chunk,
-          Link: &notionapi.Link{
-            Url: link,
-          },
-        },
-        PlainText: chunk,
-      }
+  }
+
+  return blocks
+}
+
+// Length ... Length of content as Notion counts it, in UTF-16 code units.
+func Length(content string) int {
+  length := 0
+  for _, r := range content {
+    length += utf16.RuneLen(r)
+  }
+  return length
+}
+
+// splitContent ... Split content into chunks of at most limit UTF-16 code units without breaking a rune.
+// A chunk ends at the last sentence boundary or else the last whitespace in its second half, if any.
+func splitContent(content string, limit int) []string {
+  var chunks []string
 
-      blocks = append(blocks, richText)
+  for Length(content) > limit {
+    end := splitPoint(content, limit)
+    chunks = append(chunks, content[:end])
+    content = content[end:]
+  }
+
+  return append(chunks, content)
+}
+
+// splitPoint ... Byte offset where the first chunk of content ends.
+func splitPoint(content string, limit int) int {
+  length := 0
+  hardEnd := 0
+  sentenceEnd := 0
+  spaceEnd := 0
+
+  var prev rune
+  for hardEnd < len(content) {
+    r, size := utf8.DecodeRuneInString(content[hardEnd:])
+    length += utf16.RuneLen(r)
+    if length > limit {
+      break
     }
+    hardEnd += size
+
+    switch {
+    case r == '\n', strings.ContainsRune("。！？", r), unicode.IsSpace(r) && strings.ContainsRune(".!?", prev):
+      sentenceEnd = hardEnd
+    case unicode.IsSpace(r):
+      spaceEnd = hardEnd
+    }
+    prev = r
   }
 
-  return blocks
+  // A boundary in the first half would leave the chunk too short
+  switch {
+  case sentenceEnd > hardEnd/2:
+    return sentenceEnd
+  case spaceEnd > hardEnd/2:
+    return spaceEnd
+  case hardEnd == 0:
+    // The first rune alone is longer than the limit
+    _, size := utf8.DecodeRuneInString(content)
+    return size
+  }
+  return hardEnd
 }
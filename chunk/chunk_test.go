@@ -1,7 +1,13 @@
 package chunk
 
 import (
+  "encoding/json"
+  "math/rand"
+  "reflect"
+  "strings"
   "testing"
+  "testing/quick"
+  "unicode/utf8"
 
   "github.com/jomei/notionapi"
   "github.com/stretchr/testify/assert"
@@ -68,6 +74,65 @@ func TestBlocks(t *testing.T) {
   })
 }
 
+func TestBlocksLimits(t *testing.T) {
+  // newListItem ... Create a list item with n nested children
+  newListItem := func(n int) notionapi.Block {
+    children := make([]notionapi.Block, n)
+    for i := range children {
+      children[i] = &notionapi.ParagraphBlock{BasicBlock: notionapi.BasicBlock{Type: notionapi.BlockTypeParagraph}}
+    }
+    return notionapi.BulletedListItemBlock{
+      BasicBlock:       notionapi.BasicBlock{Type: notionapi.BlockTypeBulletedListItem},
+      BulletedListItem: notionapi.ListItem{Children: children},
+    }
+  }
+
+  t.Run("batches by nested descendants", func(t *testing.T) {
+    // Each list item counts as 100 blocks with its 99 children
+    blocks := make([]notionapi.Block, 15)
+    for i := range blocks {
+      blocks[i] = newListItem(99)
+    }
+
+    result := Blocks(blocks)
+
+    assert.Len(t, result, 2)
+    assert.Len(t, result[0], 10)
+    assert.Len(t, result[1], 5)
+  })
+
+  t.Run("batches by payload size", func(t *testing.T) {
+    content := strings.Repeat("a", CharacterLimit)
+    blocks := make([]notionapi.Block, 90)
+    for i := range blocks {
+      blocks[i] = &notionapi.CodeBlock{
+        BasicBlock: notionapi.BasicBlock{Type: notionapi.BlockTypeCode},
+        Code:       notionapi.Code{RichText: RichText(strings.Repeat(content, 5), nil)},
+      }
+    }
+
+    result := Blocks(blocks)
+
+    assert.Greater(t, len(result), 1)
+    total := 0
+    for _, batch := range result {
+      data, err := json.Marshal(notionapi.AppendBlockChildrenRequest{Children: batch})
+      assert.NoError(t, err)
+      assert.LessOrEqual(t, len(data), PayloadLimit)
+      total += len(batch)
+    }
+    assert.Equal(t, len(blocks), total)
+  })
+
+  t.Run("keeps a block over the limits alone", func(t *testing.T) {
+    blocks := []notionapi.Block{newListItem(1), newListItem(DescendantLimit), newListItem(1)}
+
+    result := Blocks(blocks)
+
+    assert.Len(t, result, 3)
+  })
+}
+
 func TestRichText(t *testing.T) {
   t.Run("can convert rich text under character limit", func(t *testing.T) {
     content := "Lorem ipsum dolor sit amet, consectetur adipiscing elit."
@@ -208,3 +273,100 @@ func TestRichTextWithLink(t *testing.T) {
     }
   })
 }
+
+// unicodeText ... Random text mixing ASCII, whitespace, sentence ends, Japanese, emoji and combining marks.
+type unicodeText string
+
+// Generate ... Implement quick.Generator
+func (unicodeText) Generate(r *rand.Rand, size int) reflect.Value {
+  pieces := []string{"a", "Z", "9", " ", "\n", "\t", ". ", "!", "日本語", "。", "、", "😀", "👨‍👩‍👧", "𠮷", "é", "e\u0301"}
+  var text strings.Builder
+  for n := r.Intn(size * 100); n > 0; n-- {
+    text.WriteString(pieces[r.Intn(len(pieces))])
+  }
+  return reflect.ValueOf(unicodeText(text.String()))
+}
+
+func TestLength(t *testing.T) {
+  assert.Equal(t, 0, Length(""))
+  assert.Equal(t, 5, Length("hello"))
+  assert.Equal(t, 3, Length("日本語"))
+  assert.Equal(t, 2, Length("😀"))
+}
+
+func TestSplitContent(t *testing.T) {
+  t.Run("keeps multibyte characters whole", func(t *testing.T) {
+    content := strings.Repeat("あ", CharacterLimit+1)
+
+    chunks := splitContent(content, CharacterLimit)
+
+    assert.Equal(t, []string{strings.Repeat("あ", CharacterLimit), "あ"}, chunks)
+  })
+
+  t.Run("counts surrogate pairs as two characters", func(t *testing.T) {
+    chunks := splitContent(strings.Repeat("😀", 3), 5)
+
+    assert.Equal(t, []string{"😀😀", "😀"}, chunks)
+  })
+
+  t.Run("prefers sentence boundaries", func(t *testing.T) {
+    chunks := splitContent("First sentence. Second one here", 24)
+
+    assert.Equal(t, []string{"First sentence. ", "Second one here"}, chunks)
+  })
+
+  t.Run("prefers Japanese sentence boundaries", func(t *testing.T) {
+    chunks := splitContent("これは文です。次の文です", 10)
+
+    assert.Equal(t, []string{"これは文です。", "次の文です"}, chunks)
+  })
+
+  t.Run("falls back to whitespace", func(t *testing.T) {
+    chunks := splitContent("alpha beta gamma", 12)
+
+    assert.Equal(t, []string{"alpha beta ", "gamma"}, chunks)
+  })
+
+  t.Run("does not split decimals as sentences", func(t *testing.T) {
+    chunks := splitContent("pi is 3.14159 ok", 14)
+
+    assert.Equal(t, []string{"pi is 3.14159 ", "ok"}, chunks)
+  })
+
+  t.Run("keeps chunks within the limit, rejoinable and valid", func(t *testing.T) {
+    property := func(text unicodeText, limit uint8) bool {
+      content := string(text)
+      max := int(limit)%200 + 2
+
+      chunks := splitContent(content, max)
+      if strings.Join(chunks, "") != content {
+        return false
+      }
+      for _, chunk := range chunks {
+        if Length(chunk) > max || !utf8.ValidString(chunk) || (chunk == "" && content != "") {
+          return false
+        }
+      }
+      return true
+    }
+
+    assert.NoError(t, quick.Check(property, &quick.Config{MaxCount: 500}))
+  })
+
+  t.Run("splits rich text within the character limit", func(t *testing.T) {
+    property := func(text unicodeText) bool {
+      content := string(text)
+
+      var joined strings.Builder
+      for _, richText := range RichText(content, nil) {
+        if Length(richText.Text.Content) > CharacterLimit || !utf8.ValidString(richText.Text.Content) {
+          return false
+        }
+        joined.WriteString(richText.Text.Content)
+      }
+      return joined.String() == content
+    }
+
+    assert.NoError(t, quick.Check(property, &quick.Config{MaxCount: 100}))
+  })
+}
@@ -0,0 +1,164 @@
+package chunk
+
+import (
+  "unicode"
+  "unicode/utf8"
+
+  "github.com/jomei/notionapi"
+)
+
+// RichTextLimit ... maximum number of rich text elements in a single rich_text array.
+const RichTextLimit = 100
+
+// SplitBlocks ... Split blocks whose rich text has more than RichTextLimit elements into consecutive blocks of the same type.
+// Paragraphs, quotes, list items, to-dos and code blocks are split, including nested ones, and the number of split blocks is returned.
+// Numbered list items keep the rest of their text in child paragraphs so that the list numbering is kept.
+func SplitBlocks(blocks []notionapi.Block) ([]notionapi.Block, int) {
+  var result []notionapi.Block
+  count := 0
+
+  for _, block := range blocks {
+    pieces, n := splitBlock(block)
+    result = append(result, pieces...)
+    count += n
+  }
+
+  return result, count
+}
+
+// splitBlock ... Split a block and its children, returning the pieces and the number of split blocks.
+func splitBlock(block notionapi.Block) ([]notionapi.Block, int) {
+  switch b := block.(type) {
+  case *notionapi.ParagraphBlock:
+    children, count := SplitBlocks(b.Paragraph.Children)
+    pieces := splitRichText(b.Paragraph.RichText, func(richText []notionapi.RichText, last bool) notionapi.Block {
+      piece := *b
+      piece.Paragraph.RichText = richText
+      piece.Paragraph.Children = lastChildren(children, last)
+      return &piece
+    })
+    return pieces, count + splitCount(pieces)
+
+  case *notionapi.QuoteBlock:
+    children, count := SplitBlocks(b.Quote.Children)
+    pieces := splitRichText(b.Quote.RichText, func(richText []notionapi.RichText, last bool) notionapi.Block {
+      piece := *b
+      piece.Quote.RichText = richText
+      piece.Quote.Children = lastChildren(children, last)
+      return &piece
+    })
+    return pieces, count + splitCount(pieces)
+
+  case notionapi.BulletedListItemBlock:
+    return splitBlock(&b)
+  case *notionapi.BulletedListItemBlock:
+    children, count := SplitBlocks(b.BulletedListItem.Children)
+    pieces := splitRichText(b.BulletedListItem.RichText, func(richText []notionapi.RichText, last bool) notionapi.Block {
+      piece := *b
+      piece.BulletedListItem.RichText = richText
+      piece.BulletedListItem.Children = lastChildren(children, last)
+      return &piece
+    })
+    return pieces, count + splitCount(pieces)
+
+  case notionapi.NumberedListItemBlock:
+    return splitBlock(&b)
+  case *notionapi.NumberedListItemBlock:
+    children, count := SplitBlocks(b.NumberedListItem.Children)
+    // Consecutive numbered items would renumber the list, so the rest of the text goes into child paragraphs
+    pieces := splitRichText(b.NumberedListItem.RichText, func(richText []notionapi.RichText, last bool) notionapi.Block {
+      return &notionapi.ParagraphBlock{
+        BasicBlock: notionapi.BasicBlock{Object: notionapi.ObjectTypeBlock, Type: notionapi.BlockTypeParagraph},
+        Paragraph:  notionapi.Paragraph{RichText: richText},
+      }
+    })
+    item := *b
+    item.NumberedListItem.RichText = pieces[0].(*notionapi.ParagraphBlock).Paragraph.RichText
+    item.NumberedListItem.Children = append(pieces[1:len(pieces):len(pieces)], children...)
+    return []notionapi.Block{&item}, count + splitCount(pieces)
+
+  case notionapi.ToDoBlock:
+    return splitBlock(&b)
+  case *notionapi.ToDoBlock:
+    children, count := SplitBlocks(b.ToDo.Children)
+    pieces := splitRichText(b.ToDo.RichText, func(richText []notionapi.RichText, last bool) notionapi.Block {
+      piece := *b
+      piece.ToDo.RichText = richText
+      piece.ToDo.Children = lastChildren(children, last)
+      return &piece
+    })
+    return pieces, count + splitCount(pieces)
+
+  case *notionapi.CodeBlock:
+    pieces := splitRichText(b.Code.RichText, func(richText []notionapi.RichText, last bool) notionapi.Block {
+      piece := *b
+      piece.Code.RichText = richText
+      // Keep the caption under the last piece
+      if !last {
+        piece.Code.Caption = nil
+      }
+      return &piece
+    })
+    return pieces, splitCount(pieces)
+
+  case *notionapi.ToggleBlock:
+    children, count := SplitBlocks(b.Toggle.Children)
+    piece := *b
+    piece.Toggle.Children = children
+    return []notionapi.Block{&piece}, count
+
+  case *notionapi.CalloutBlock:
+    children, count := SplitBlocks(b.Callout.Children)
+    piece := *b
+    piece.Callout.Children = children
+    return []notionapi.Block{&piece}, count
+  }
+
+  return []notionapi.Block{block}, 0
+}
+
+// splitRichText ... Split rich text into pieces of at most RichTextLimit elements and create a block for each piece.
+func splitRichText(richText []notionapi.RichText, newBlock func(richText []notionapi.RichText, last bool) notionapi.Block) []notionapi.Block {
+  var blocks []notionapi.Block
+
+  for len(richText) > RichTextLimit {
+    end := richTextSplitPoint(richText)
+    blocks = append(blocks, newBlock(richText[:end], false))
+    richText = richText[end:]
+  }
+
+  return append(blocks, newBlock(richText, true))
+}
+
+// richTextSplitPoint ... Number of elements in the first piece of rich text.
+// The piece ends after the last element ending a line, or else a word, in the second half of RichTextLimit.
+func richTextSplitPoint(richText []notionapi.RichText) int {
+  for _, isBoundary := range []func(r rune) bool{
+    func(r rune) bool { return r == '\n' },
+    unicode.IsSpace,
+  } {
+    for end := RichTextLimit; end > RichTextLimit/2; end-- {
+      last, _ := utf8.DecodeLastRuneInString(richText[end-1].PlainText)
+      if isBoundary(last) {
+        return end
+      }
+    }
+  }
+  return RichTextLimit
+}
+
+// lastChildren ... Children of a piece, which only the last piece of a split block keeps.
+func lastChildren(children []notionapi.Block, last bool) []notionapi.Block {
+  if !last {
+    return nil
+  }
+  return children
+}
+
+// splitCount ... Number of split blocks a list of pieces stands for.
+func splitCount(pieces []notionapi.Block) int {
+  if len(pieces) > 1 {
+    return 1
+  }
+  return 0
+}
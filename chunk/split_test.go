@@ -0,0 +1,164 @@
+package chunk
+
+import (
+  "testing"
+
+  "github.com/jomei/notionapi"
+  "github.com/stretchr/testify/assert"
+)
+
+// newRichTexts ... Create n rich text elements with the given contents repeated
+func newRichTexts(n int, contents ...string) []notionapi.RichText {
+  richText := make([]notionapi.RichText, n)
+  for i := range richText {
+    content := contents[i%len(contents)]
+    richText[i] = notionapi.RichText{Type: notionapi.ObjectTypeText, Text: &notionapi.Text{Content: content}, PlainText: content}
+  }
+  return richText
+}
+
+func TestSplitBlocks(t *testing.T) {
+  t.Run("keeps blocks within the limit", func(t *testing.T) {
+    blocks := []notionapi.Block{
+      &notionapi.ParagraphBlock{Paragraph: notionapi.Paragraph{RichText: newRichTexts(RichTextLimit, "a")}},
+    }
+
+    result, count := SplitBlocks(blocks)
+
+    assert.Equal(t, blocks, result)
+    assert.Equal(t, 0, count)
+  })
+
+  t.Run("splits paragraphs into consecutive paragraphs", func(t *testing.T) {
+    richText := newRichTexts(RichTextLimit*2+10, "a")
+    blocks := []notionapi.Block{
+      &notionapi.ParagraphBlock{
+        BasicBlock: notionapi.BasicBlock{Object: notionapi.ObjectTypeBlock, Type: notionapi.BlockTypeParagraph},
+        Paragraph: notionapi.Paragraph{
+          RichText: richText,
+          Color:    "blue",
+          Children: []notionapi.Block{&notionapi.DividerBlock{}},
+        },
+      },
+    }
+
+    result, count := SplitBlocks(blocks)
+
+    assert.Equal(t, 1, count)
+    assert.Len(t, result, 3)
+    var joined []notionapi.RichText
+    for i, block := range result {
+      paragraph := block.(*notionapi.ParagraphBlock)
+      assert.Equal(t, notionapi.BlockTypeParagraph, paragraph.Type)
+      assert.Equal(t, "blue", paragraph.Paragraph.Color)
+      assert.LessOrEqual(t, len(paragraph.Paragraph.RichText), RichTextLimit)
+      if i < 2 {
+        assert.Empty(t, paragraph.Paragraph.Children)
+      } else {
+        assert.Len(t, paragraph.Paragraph.Children, 1)
+      }
+      joined = append(joined, paragraph.Paragraph.RichText...)
+    }
+    assert.Equal(t, richText, joined)
+  })
+
+  t.Run("splits after line ends", func(t *testing.T) {
+    blocks := []notionapi.Block{
+      &notionapi.CodeBlock{Code: notionapi.Code{
+        RichText: newRichTexts(RichTextLimit+1, "line\n", "a", "b ", "c"),
+        Language: "go",
+        Caption:  newRichTexts(1, "main.go"),
+      }},
+    }
+
+    result, count := SplitBlocks(blocks)
+
+    assert.Equal(t, 1, count)
+    assert.Len(t, result, 2)
+    first, second := result[0].(*notionapi.CodeBlock), result[1].(*notionapi.CodeBlock)
+    assert.Len(t, first.Code.RichText, 97)
+    assert.Equal(t, "line\n", first.Code.RichText[96].PlainText)
+    assert.Equal(t, "go", second.Code.Language)
+    assert.Empty(t, first.Code.Caption)
+    assert.Equal(t, "main.go", second.Code.Caption[0].PlainText)
+  })
+
+  t.Run("splits after words without line ends", func(t *testing.T) {
+    blocks := []notionapi.Block{
+      &notionapi.QuoteBlock{Quote: notionapi.Quote{RichText: newRichTexts(RichTextLimit+1, "a", "b ", "c")}},
+    }
+
+    result, _ := SplitBlocks(blocks)
+
+    assert.Len(t, result[0].(*notionapi.QuoteBlock).Quote.RichText, 98)
+  })
+
+  t.Run("splits list items and nested children", func(t *testing.T) {
+    blocks := []notionapi.Block{
+      notionapi.BulletedListItemBlock{BulletedListItem: notionapi.ListItem{
+        RichText: newRichTexts(1, "parent"),
+        Children: []notionapi.Block{
+          notionapi.BulletedListItemBlock{BulletedListItem: notionapi.ListItem{RichText: newRichTexts(RichTextLimit+1, "a")}},
+        },
+      }},
+      &notionapi.ToggleBlock{Toggle: notionapi.Toggle{Children: []notionapi.Block{
+        notionapi.ToDoBlock{ToDo: notionapi.ToDo{RichText: newRichTexts(RichTextLimit+1, "a"), Checked: true}},
+      }}},
+    }
+
+    result, count := SplitBlocks(blocks)
+
+    assert.Equal(t, 2, count)
+    assert.Len(t, result, 2)
+    children := result[0].(*notionapi.BulletedListItemBlock).BulletedListItem.Children
+    assert.Len(t, children, 2)
+    assert.Len(t, children[1].(*notionapi.BulletedListItemBlock).BulletedListItem.RichText, 1)
+    toggleChildren := result[1].(*notionapi.ToggleBlock).Toggle.Children
+    assert.Len(t, toggleChildren, 2)
+    assert.True(t, toggleChildren[0].(*notionapi.ToDoBlock).ToDo.Checked)
+  })
+
+  t.Run("keeps the numbering of numbered list items", func(t *testing.T) {
+    richText := newRichTexts(RichTextLimit*2+10, "a")
+    blocks := []notionapi.Block{
+      notionapi.NumberedListItemBlock{NumberedListItem: notionapi.ListItem{
+        RichText: richText,
+        Children: []notionapi.Block{&notionapi.DividerBlock{}},
+      }},
+      notionapi.NumberedListItemBlock{NumberedListItem: notionapi.ListItem{RichText: newRichTexts(1, "second")}},
+    }
+
+    result, count := SplitBlocks(blocks)
+
+    assert.Equal(t, 1, count)
+    assert.Len(t, result, 2)
+    item := result[0].(*notionapi.NumberedListItemBlock)
+    assert.Len(t, item.NumberedListItem.RichText, RichTextLimit)
+    children := item.NumberedListItem.Children
+    assert.Len(t, children, 3)
+    joined := item.NumberedListItem.RichText
+    for _, child := range children[:2] {
+      joined = append(joined, child.(*notionapi.ParagraphBlock).Paragraph.RichText...)
+    }
+    assert.Equal(t, richText, joined)
+    assert.IsType(t, &notionapi.DividerBlock{}, children[2])
+    assert.Equal(t, "second", result[1].GetRichTextString())
+  })
+
+  t.Run("leaves the input untouched", func(t *testing.T) {
+    paragraph := &notionapi.ParagraphBlock{Paragraph: notionapi.Paragraph{RichText: newRichTexts(RichTextLimit+1, "a")}}
+
+    SplitBlocks([]notionapi.Block{paragraph})
+
+    assert.Len(t, paragraph.Paragraph.RichText, RichTextLimit+1)
+  })
+
+  t.Run("keeps blocks of other types", func(t *testing.T) {
+    blocks := []notionapi.Block{&notionapi.Heading1Block{Heading1: notionapi.Heading{RichText: newRichTexts(RichTextLimit+1, "a")}}}
+
+    result, count := SplitBlocks(blocks)
+
+    assert.Equal(t, blocks, result)
+    assert.Equal(t, 0, count)
+  })
+}
@@ -0,0 +1,124 @@
+package converter
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/jomei/notionapi"
+	"github.com/yuin/goldmark/ast"
+)
+
+// AlertStyle is the look of the callout a GitHub alert is converted to.
+type AlertStyle struct {
+	Emoji string
+	Color string
+}
+
+// DefaultAlertStyles maps the GitHub alert kinds to callout styles.
+var DefaultAlertStyles = map[string]AlertStyle{
+	"NOTE":      {Emoji: "ℹ️", Color: "blue_background"},
+	"TIP":       {Emoji: "💡", Color: "green_background"},
+	"IMPORTANT": {Emoji: "❗", Color: "purple_background"},
+	"WARNING":   {Emoji: "⚠️", Color: "yellow_background"},
+	"CAUTION":   {Emoji: "🛑", Color: "red_background"},
+}
+
+// alertMarkerRegexp matches the first line of a GitHub alert like "[!NOTE]".
+var alertMarkerRegexp = regexp.MustCompile(`^\[!([A-Za-z]+)\]$`)
+
+// ParseAlertStyle parses an alert style option like "NOTE=📝:blue_background".
+func ParseAlertStyle(option string) (string, AlertStyle, error) {
+	kind, value, ok := strings.Cut(option, "=")
+	if !ok || kind == "" {
+		return "", AlertStyle{}, fmt.Errorf("invalid alert style %q: expected KIND=EMOJI:COLOR", option)
+	}
+
+	emoji, color, ok := strings.Cut(value, ":")
+	if !ok || emoji == "" || color == "" {
+		return "", AlertStyle{}, fmt.Errorf("invalid alert style %q: expected KIND=EMOJI:COLOR", option)
+	}
+
+	return strings.ToUpper(kind), AlertStyle{Emoji: emoji, Color: color}, nil
+}
+
+// alertStyles returns the default alert styles overridden by the configured ones.
+func (c *Converter) alertStyles() map[string]AlertStyle {
+	styles := make(map[string]AlertStyle, len(DefaultAlertStyles)+len(c.AlertStyles))
+	for kind, style := range DefaultAlertStyles {
+		styles[kind] = style
+	}
+	for kind, style := range c.AlertStyles {
+		styles[strings.ToUpper(kind)] = style
+	}
+	return styles
+}
+
+// alertKind returns the kind of a GitHub alert blockquote like "NOTE",
+// or an empty string if the blockquote is not an alert.
+func alertKind(node *ast.Blockquote, source []byte) string {
+	if node == nil {
+		return ""
+	}
+
+	paragraph, ok := node.FirstChild().(*ast.Paragraph)
+	if !ok || paragraph.Lines().Len() == 0 {
+		return ""
+	}
+
+	firstLine := paragraph.Lines().At(0)
+	match := alertMarkerRegexp.FindSubmatch([]byte(strings.TrimSpace(string(firstLine.Value(source)))))
+	if match == nil {
+		return ""
+	}
+	return strings.ToUpper(string(match[1]))
+}
+
+// stripAlertMarker removes the "[!KIND]" line from the first paragraph of an alert blockquote.
+func stripAlertMarker(node *ast.Blockquote) {
+	paragraph := node.FirstChild().(*ast.Paragraph)
+	markerStop := paragraph.Lines().At(0).Stop
+
+	for child := paragraph.FirstChild(); child != nil; {
+		next := child.NextSibling()
+		// The marker line only holds text, so anything else belongs to the body
+		if text, ok := child.(*ast.Text); !ok || text.Segment.Stop > markerStop {
+			break
+		}
+		paragraph.RemoveChild(paragraph, child)
+		child = next
+	}
+
+	if paragraph.ChildCount() == 0 {
+		node.RemoveChild(node, paragraph)
+	}
+}
+
+// alertTitle returns the title shown for an alert kind, like "Note" for "NOTE".
+func alertTitle(kind string) string {
+	return strings.ToUpper(kind[:1]) + strings.ToLower(kind[1:])
+}
+
+// convertAlert converts a GitHub alert to a Notion callout block with the body blocks as children.
+func convertAlert(kind string, style AlertStyle, children []notionapi.Block) *notionapi.CalloutBlock {
+	if kind == "" {
+		return nil
+	}
+
+	emoji := notionapi.Emoji(style.Emoji)
+	return &notionapi.CalloutBlock{
+		BasicBlock: notionapi.BasicBlock{
+			Object: notionapi.ObjectTypeBlock,
+			Type:   notionapi.BlockTypeCallout,
+		},
+		Callout: notionapi.Callout{
+			RichText: newStyledRichText(alertTitle(kind), inlineStyle{annotations: notionapi.Annotations{Bold: true}}),
+			Icon: &notionapi.Icon{
+				Type:  "emoji",
+				Emoji: &emoji,
+			},
+			Children: children,
+			Color:    style.Color,
+		},
+	}
+}
@@ -0,0 +1,93 @@
+package converter
+
+import (
+	"testing"
+
+	"github.com/jomei/notionapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/yuin/goldmark/ast"
+)
+
+func TestAlertKind(t *testing.T) {
+	t.Run("detects alert marker", func(t *testing.T) {
+		source := []byte("> [!warning]\n> Be careful")
+		node := parseMarkdown(source).FirstChild().(*ast.Blockquote)
+		assert.Equal(t, "WARNING", alertKind(node, source))
+	})
+
+	t.Run("ignores plain blockquote", func(t *testing.T) {
+		source := []byte("> Just a quote [!NOTE]")
+		node := parseMarkdown(source).FirstChild().(*ast.Blockquote)
+		assert.Equal(t, "", alertKind(node, source))
+	})
+
+	t.Run("handles nil blockquote", func(t *testing.T) {
+		assert.Equal(t, "", alertKind(nil, nil))
+	})
+}
+
+func TestParseAlertStyle(t *testing.T) {
+	t.Run("parses kind, emoji and color", func(t *testing.T) {
+		kind, style, err := ParseAlertStyle("note=📝:gray_background")
+		assert.NoError(t, err)
+		assert.Equal(t, "NOTE", kind)
+		assert.Equal(t, AlertStyle{Emoji: "📝", Color: "gray_background"}, style)
+	})
+
+	t.Run("returns error for invalid option", func(t *testing.T) {
+		_, _, err := ParseAlertStyle("NOTE")
+		assert.Error(t, err)
+
+		_, _, err = ParseAlertStyle("NOTE=📝")
+		assert.Error(t, err)
+	})
+}
+
+func TestConvertAlert(t *testing.T) {
+	t.Run("converts alerts to callouts with body blocks as children", func(t *testing.T) {
+		blocks := convertMarkdown(t, &Converter{}, "> [!NOTE]\n> Useful **information**.\n>\n> - first\n> - second\n")
+
+		assert.Len(t, blocks, 1)
+		callout, ok := blocks[0].(*notionapi.CalloutBlock)
+		assert.True(t, ok)
+		assert.Equal(t, notionapi.BlockTypeCallout, callout.Type)
+		assert.Equal(t, "Note", callout.GetRichTextString())
+		assert.Equal(t, notionapi.Emoji("ℹ️"), *callout.Callout.Icon.Emoji)
+		assert.Equal(t, "blue_background", callout.Callout.Color)
+
+		assert.Len(t, callout.Callout.Children, 3)
+		assert.Equal(t, "Useful information.", callout.Callout.Children[0].GetRichTextString())
+		assert.True(t, findRichText(t, callout.Callout.Children[0].(*notionapi.ParagraphBlock).Paragraph.RichText, "information").Annotations.Bold)
+		assert.Equal(t, notionapi.BlockTypeBulletedListItem, callout.Callout.Children[1].GetType())
+	})
+
+	t.Run("uses style per alert kind", func(t *testing.T) {
+		for kind, style := range DefaultAlertStyles {
+			blocks := convertMarkdown(t, &Converter{}, "> [!"+kind+"]\n> Body\n")
+
+			callout := blocks[0].(*notionapi.CalloutBlock)
+			assert.Equal(t, notionapi.Emoji(style.Emoji), *callout.Callout.Icon.Emoji, kind)
+			assert.Equal(t, style.Color, callout.Callout.Color, kind)
+			assert.Equal(t, "Body", callout.Callout.Children[0].GetRichTextString(), kind)
+		}
+	})
+
+	t.Run("uses configured styles", func(t *testing.T) {
+		c := &Converter{AlertStyles: map[string]AlertStyle{
+			"tip":    {Emoji: "✅", Color: "gray_background"},
+			"DANGER": {Emoji: "🔥", Color: "red_background"},
+		}}
+		blocks := convertMarkdown(t, c, "> [!TIP]\n> Body\n\n> [!DANGER]\n> Hot\n")
+
+		assert.Len(t, blocks, 2)
+		assert.Equal(t, notionapi.Emoji("✅"), *blocks[0].(*notionapi.CalloutBlock).Callout.Icon.Emoji)
+		assert.Equal(t, "Danger", blocks[1].GetRichTextString())
+	})
+
+	t.Run("keeps unknown kinds as quotes", func(t *testing.T) {
+		blocks := convertMarkdown(t, &Converter{}, "> [!UNKNOWN]\n> Body\n")
+
+		assert.Len(t, blocks, 1)
+		assert.Equal(t, notionapi.BlockTypeQuote, blocks[0].GetType())
+	})
+}
@@ -11,32 +11,42 @@ func isBlockquote(node ast.Node) bool {
   return ok
 }
 
-func convertBlockquote(node *ast.Blockquote, source []byte) *notionapi.QuoteBlock {
+// quoteBodyStart returns the first child of a blockquote that is converted to a child block.
+// A leading paragraph becomes the rich text of the quote itself.
+func quoteBodyStart(node *ast.Blockquote) ast.Node {
+  first := node.FirstChild()
+  if isParagraph(first) {
+    return first.NextSibling()
+  }
+  return first
+}
+
+// convertBlockquote converts a blockquote node to a Notion quote block.
+// The first paragraph becomes the quote text and children holds the blocks converted from the rest.
+func convertBlockquote(node *ast.Blockquote, source []byte, children []notionapi.Block) *notionapi.QuoteBlock {
   if node == nil {
     return nil
   }
 
   var richTextBlocks []notionapi.RichText
-  for child := node.FirstChild(); child != nil; child = child.NextSibling() {
-    // Skip nested blockquotes as they will be handled separately
-    if _, isBQ := child.(*ast.Blockquote); isBQ {
-      continue
-    }
-
-    childBlocks := convertChildNodesToRichText(child, source)
-    if childBlocks != nil {
-      richTextBlocks = append(richTextBlocks, childBlocks...)
+  if paragraph, ok := node.FirstChild().(*ast.Paragraph); ok {
+    if paragraphBlock := convertParagraph(paragraph, source); paragraphBlock != nil {
+      richTextBlocks = paragraphBlock.Paragraph.RichText
     }
   }
 
-  if len(richTextBlocks) == 0 {
+  if len(richTextBlocks) == 0 && len(children) == 0 {
     // Extract text directly from the blockquote if no rich text blocks were created
-    content := string(source)
-    if content != "" {
-      richTextBlocks = chunk.RichText(content, nil)
-    } else {
+    content := string(node.Lines().Value(source))
+    if content == "" {
       return nil
     }
+    richTextBlocks = chunk.RichText(content, nil)
+  }
+
+  // Notion requires rich text even when the quote starts with a child block
+  if richTextBlocks == nil {
+    richTextBlocks = []notionapi.RichText{}
   }
 
   return &notionapi.QuoteBlock{
@@ -46,6 +56,7 @@ func convertBlockquote(node *ast.Blockquote, source []byte) *notionapi.QuoteBloc
     },
     Quote: notionapi.Quote{
       RichText: richTextBlocks,
+      Children: children,
       Color:    "default",
     },
   }
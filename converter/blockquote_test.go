@@ -45,7 +45,7 @@ func TestConvertBlockquote(t *testing.T) {
     blockquoteNode.AppendChild(blockquoteNode, paragraph)
 
     // Execute the function
-    quoteBlock := convertBlockquote(blockquoteNode, source)
+    quoteBlock := convertBlockquote(blockquoteNode, source, nil)
 
     // Verify the result
     assert.NotNil(t, quoteBlock, "Expected quote block to not be nil")
@@ -55,7 +55,7 @@ func TestConvertBlockquote(t *testing.T) {
   })
 
   t.Run("handles nil blockquote", func(t *testing.T) {
-    quoteBlock := convertBlockquote(nil, nil)
+    quoteBlock := convertBlockquote(nil, nil, nil)
     assert.Nil(t, quoteBlock, "Expected quote block to be nil for nil blockquote")
   })
 
@@ -66,7 +66,7 @@ func TestConvertBlockquote(t *testing.T) {
     }
 
     // Execute the function
-    quoteBlock := convertBlockquote(blockquoteNode, []byte{})
+    quoteBlock := convertBlockquote(blockquoteNode, []byte{}, nil)
 
     // Since there's no content, the function should return nil
     assert.Nil(t, quoteBlock, "Expected quote block to be nil for empty blockquote")
@@ -103,7 +103,7 @@ func TestConvertBlockquote(t *testing.T) {
 
     // Set up the lines for the nested paragraph
     nestedLines := text.NewSegments()
-    nestedLines.Append(text.NewSegment(21, 38)) // "Nested blockquote"の部分
+    nestedLines.Append(text.NewSegment(22, 39)) // "Nested blockquote"の部分
     nestedParagraph.SetLines(nestedLines)
 
     // Set up the AST structure
@@ -111,21 +111,27 @@ func TestConvertBlockquote(t *testing.T) {
     nestedBlockquote.AppendChild(nestedBlockquote, nestedParagraph)
     outerBlockquote.AppendChild(outerBlockquote, nestedBlockquote)
 
+    // Convert the nested blockquote as a child block
+    children := []notionapi.Block{convertBlockquote(nestedBlockquote, source, nil)}
+
     // Execute the function
-    quoteBlock := convertBlockquote(outerBlockquote, source)
+    quoteBlock := convertBlockquote(outerBlockquote, source, children)
 
     // Verify the result
     assert.NotNil(t, quoteBlock, "Expected quote block to not be nil")
     assert.Equal(t, notionapi.BlockTypeQuote, quoteBlock.Type, "Expected block type to be quote")
 
-  		// Check if the content is correctly extracted
+    // Check if the content is correctly extracted
     richTextContent := ""
     for _, rt := range quoteBlock.Quote.RichText {
       richTextContent += rt.PlainText
     }
-  		assert.Contains(t, richTextContent, "Outer blockquote", "Expected rich text to contain outer blockquote text")
-  		// Note: In the current implementation, nested blockquotes are included in the rich text
-  		assert.Contains(t, richTextContent, "Nested blockquote", "Expected rich text to contain nested blockquote text")
+    assert.Contains(t, richTextContent, "Outer blockquote", "Expected rich text to contain outer blockquote text")
+    assert.NotContains(t, richTextContent, "Nested blockquote", "Expected rich text to not contain nested blockquote text")
+
+    // Check if the nested blockquote is kept as a child
+    assert.Len(t, quoteBlock.Quote.Children, 1)
+    assert.Equal(t, "Nested blockquote", quoteBlock.Quote.Children[0].GetRichTextString())
   })
 
   t.Run("handles blockquote with direct text content", func(t *testing.T) {
@@ -143,7 +149,7 @@ func TestConvertBlockquote(t *testing.T) {
     blockquoteNode.SetLines(lines)
 
     // Execute the function
-    quoteBlock := convertBlockquote(blockquoteNode, source)
+    quoteBlock := convertBlockquote(blockquoteNode, source, nil)
 
     // Verify the result
     assert.NotNil(t, quoteBlock, "Expected quote block to not be nil")
@@ -158,3 +164,33 @@ func TestConvertBlockquote(t *testing.T) {
     assert.Contains(t, richTextContent, "Direct text content", "Expected rich text to contain the direct text content")
   })
 }
+
+func TestConvertBlockquoteChildren(t *testing.T) {
+  t.Run("keeps first paragraph as text and the rest as children", func(t *testing.T) {
+    markdown := "> Review notes\n> wrapped line\n>\n> Second paragraph\n>\n> - point\n>\n> ```go\n> fmt.Println()\n> ```\n>\n> > nested quote\n"
+    blocks := convertMarkdown(t, &Converter{}, markdown)
+
+    assert.Len(t, blocks, 1)
+    quote, ok := blocks[0].(*notionapi.QuoteBlock)
+    assert.True(t, ok)
+    assert.Equal(t, "Review notes wrapped line", quote.GetRichTextString())
+
+    children := quote.Quote.Children
+    assert.Len(t, children, 4)
+    assert.Equal(t, notionapi.BlockTypeParagraph, children[0].GetType())
+    assert.Equal(t, "Second paragraph", children[0].GetRichTextString())
+    assert.Equal(t, notionapi.BlockTypeBulletedListItem, children[1].GetType())
+    assert.Equal(t, notionapi.BlockTypeCode, children[2].GetType())
+    assert.Equal(t, notionapi.BlockTypeQuote, children[3].GetType())
+    assert.Equal(t, "nested quote", children[3].GetRichTextString())
+  })
+
+  t.Run("keeps all blocks as children when quote starts with a list", func(t *testing.T) {
+    blocks := convertMarkdown(t, &Converter{}, "> - first\n> - second\n")
+
+    quote := blocks[0].(*notionapi.QuoteBlock)
+    assert.NotNil(t, quote.Quote.RichText)
+    assert.Empty(t, quote.Quote.RichText)
+    assert.Len(t, quote.Quote.Children, 2)
+  })
+}
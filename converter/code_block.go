@@ -0,0 +1,36 @@
+package converter
+
+import (
+	"github.com/jomei/notionapi"
+	"github.com/sioncojp/go-markdown-to-notion/chunk"
+	"github.com/yuin/goldmark/ast"
+)
+
+// isIndentedCodeBlock checks if a node is an indented code block.
+func isIndentedCodeBlock(node ast.Node) bool {
+	_, ok := node.(*ast.CodeBlock)
+	return ok
+}
+
+// convertIndentedCodeBlock converts an indented code block node to a Notion code block in plain text.
+func convertIndentedCodeBlock(node *ast.CodeBlock, source []byte) *notionapi.CodeBlock {
+	if node == nil {
+		return nil
+	}
+
+	content := string(node.Lines().Value(source))
+	if content == "" {
+		return nil
+	}
+
+	return &notionapi.CodeBlock{
+		BasicBlock: notionapi.BasicBlock{
+			Object: notionapi.ObjectTypeBlock,
+			Type:   notionapi.BlockTypeCode,
+		},
+		Code: notionapi.Code{
+			RichText: chunk.RichText(content, nil),
+			Language: PlainTextLanguage,
+		},
+	}
+}
@@ -0,0 +1,46 @@
+package converter
+
+import (
+	"testing"
+
+	"github.com/jomei/notionapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/yuin/goldmark/ast"
+)
+
+func TestIsIndentedCodeBlock(t *testing.T) {
+	t.Run("is an indented code block node", func(t *testing.T) {
+		node := &ast.CodeBlock{}
+		assert.True(t, isIndentedCodeBlock(node))
+	})
+
+	t.Run("not an indented code block node", func(t *testing.T) {
+		node := &ast.FencedCodeBlock{}
+		assert.False(t, isIndentedCodeBlock(node))
+	})
+}
+
+func TestConvertIndentedCodeBlock(t *testing.T) {
+	t.Run("handles nil code block", func(t *testing.T) {
+		assert.Nil(t, convertIndentedCodeBlock(nil, nil))
+	})
+
+	t.Run("converts indented code to plain text code block", func(t *testing.T) {
+		source := []byte("    make build\n    make test\n")
+		node := parseMarkdown(source).FirstChild().(*ast.CodeBlock)
+
+		result := convertIndentedCodeBlock(node, source)
+
+		assert.NotNil(t, result)
+		assert.Equal(t, notionapi.BlockTypeCode, result.Type)
+		assert.Equal(t, PlainTextLanguage, result.Code.Language)
+		assert.Equal(t, "make build\nmake test\n", result.Code.RichText[0].PlainText)
+	})
+
+	t.Run("converts indented code in a document", func(t *testing.T) {
+		blocks := convertMarkdown(t, &Converter{}, "Run:\n\n    make build\n")
+
+		assert.Len(t, blocks, 2)
+		assert.Equal(t, notionapi.BlockTypeCode, blocks[1].GetType())
+	})
+}
@@ -5,7 +5,6 @@ import (
   "os"
 
   "github.com/jomei/notionapi"
-  "github.com/sioncojp/go-markdown-to-notion/chunk"
   "github.com/yuin/goldmark"
   "github.com/yuin/goldmark/ast"
   "github.com/yuin/goldmark/extension"
@@ -18,86 +17,304 @@ type Converter struct {
   H1Color          string
   H2Color          string
   H3Color          string
+
+  // DeepHeadingStyle decides how headings of level 4 to 6 are converted.
+  // One of DeepHeadingHeading3 (default), DeepHeadingParagraph or DeepHeadingToggle.
+  DeepHeadingStyle string
+
+  // AlertStyles overrides the callout styles of GitHub alerts like "> [!NOTE]" by alert kind.
+  // Kinds missing here fall back to DefaultAlertStyles.
+  AlertStyles map[string]AlertStyle
+
+  // HTMLBlockPolicy decides how raw HTML blocks are converted.
+  // One of HTMLBlockDrop (default), HTMLBlockCode or HTMLBlockParse.
+  HTMLBlockPolicy string
+
+  // LanguageAliases maps code fence tags like "tpl" to Notion languages.
+  // They take precedence over DefaultLanguageAliases.
+  LanguageAliases map[string]string
+
+  // SoftLineBreakAsNewline keeps soft line breaks of wrapped lines as newlines instead of spaces.
+  SoftLineBreakAsNewline bool
+
+  // ImageUploader uploads local and data URI images.
+  // When nil, image destinations are passed to Notion as external URLs.
+  ImageUploader ImageUploader
+}
+
+// Document is a converted markdown file.
+type Document struct {
+  // FrontMatter holds the YAML front matter, or nil when the file has none.
+  FrontMatter FrontMatter
+
+  // Heading is the text of the first level 1 heading, or empty when the file has none.
+  Heading string
+
+  Blocks []notionapi.Block
 }
 
 func Convert(c *Converter) ([]notionapi.Block, error) {
+  document, err := ConvertDocument(c)
+  if err != nil {
+    return nil, err
+  }
+  return document.Blocks, nil
+}
+
+// ConvertDocument converts the markdown file to Notion blocks along with its front matter.
+func ConvertDocument(c *Converter) (*Document, error) {
   // Read the markdown file
   source, err := os.ReadFile(c.MarkdownFilePath)
   if err != nil {
     return nil, fmt.Errorf("failed to read markdown file: %w", err)
   }
 
-  // Create a new goldmark instance with table extension
-  md := goldmark.New(
-    goldmark.WithExtensions(extension.Table),
+  if !validateDeepHeadingStyle(c.DeepHeadingStyle) {
+    return nil, fmt.Errorf("unknown deep heading style: %s", c.DeepHeadingStyle)
+  }
+
+  if !validateHTMLBlockPolicy(c.HTMLBlockPolicy) {
+    return nil, fmt.Errorf("unknown html block policy: %s", c.HTMLBlockPolicy)
+  }
+
+  frontMatter, source, err := splitFrontMatter(source)
+  if err != nil {
+    return nil, err
+  }
+
+  node := newMarkdown().Parser().Parse(text.NewReader(source))
+  if c.SoftLineBreakAsNewline {
+    hardenSoftLineBreaks(node)
+  }
+
+  blocks, err := c.convertBlocks(node, source)
+  if err != nil {
+    return nil, err
+  }
+  normalizeBlocks(blocks)
+
+  return &Document{FrontMatter: frontMatter, Heading: firstHeading(node, source), Blocks: blocks}, nil
+}
+
+// newMarkdown creates a new goldmark instance with the extensions the converter supports.
+func newMarkdown() goldmark.Markdown {
+  return goldmark.New(
+    goldmark.WithExtensions(
+      extension.Table,
+      extension.TaskList,
+      extension.Strikethrough,
+      extension.Footnote,
+      &inlineStyles{},
+      &math{},
+    ),
   )
-  document := md.Parser().Parse(text.NewReader(source))
+}
 
+// convertBlocks converts the block-level children of a given AST node to Notion blocks.
+func (c *Converter) convertBlocks(node ast.Node, source []byte) ([]notionapi.Block, error) {
+  return c.convertSiblings(node.FirstChild(), source)
+}
+
+// convertSiblings converts a node and all of its following siblings to Notion blocks.
+func (c *Converter) convertSiblings(node ast.Node, source []byte) ([]notionapi.Block, error) {
+  return c.convertSiblingsUntil(node, nil, source)
+}
+
+// convertSiblingsUntil converts a node and its next siblings up to, but not including, end.
+func (c *Converter) convertSiblingsUntil(node ast.Node, end ast.Node, source []byte) ([]notionapi.Block, error) {
   // Create a slice to store the Notion blocks
   var blocks []notionapi.Block
 
-  // Walk through the AST and convert each node to a Notion block
-  ast.Walk(document, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
-    if !entering {
-      return ast.WalkContinue, nil
-    }
+  for child := node; child != end; {
+    // Deep headings as toggles take the rest of their section as children
+    if isDeepHeading(child) && c.DeepHeadingStyle == DeepHeadingToggle {
+      heading := child.(*ast.Heading)
 
-    // Handle code blocks
-    if isCodeBlock(node) {
-      codeBlock := convertFencedCodeBlock(node.(*ast.FencedCodeBlock), source)
-      if codeBlock != nil {
-        blocks = append(blocks, codeBlock)
+      sectionEnd := child.NextSibling()
+      for sectionEnd != end && !endsSection(sectionEnd, heading.Level) {
+        sectionEnd = sectionEnd.NextSibling()
       }
-      return ast.WalkSkipChildren, nil
-    }
 
-    if isHeading(node) {
-      block := convertHeading(node.(*ast.Heading), source, c.H1Color, c.H2Color, c.H3Color)
-      if block != nil {
-        blocks = append(blocks, block)
+      // Deeper headings in the section become toggles nested in this one
+      section, err := c.convertSiblingsUntil(child.NextSibling(), sectionEnd, source)
+      if err != nil {
+        return nil, err
       }
-      return ast.WalkSkipChildren, nil
-    }
 
-    if isList(node) {
-      listBlocks := convertList(node.(*ast.List), source)
-      if len(listBlocks) > 0 {
-        blocks = append(blocks, listBlocks...)
+      if toggleBlock := convertDeepHeadingToggle(heading, source, section); toggleBlock != nil {
+        blocks = append(blocks, toggleBlock)
+      } else {
+        blocks = append(blocks, section...)
       }
-      return ast.WalkSkipChildren, nil
+      child = sectionEnd
+      continue
     }
 
-    if isBlockquote(node) {
-      quoteBlock := convertBlockquote(node.(*ast.Blockquote), source)
-      if quoteBlock != nil {
-        blocks = append(blocks, quoteBlock)
+    // details elements spread over HTML blocks take the blocks up to their closing tag as children
+    if c.HTMLBlockPolicy == HTMLBlockParse && detailsDepth(child, source) > 0 {
+      if closer := detailsEnd(child, end, source); closer != nil {
+        section, err := c.convertDetailsSection(child.(*ast.HTMLBlock), closer, source)
+        if err != nil {
+          return nil, err
+        }
+        blocks = append(blocks, section...)
+        child = closer.NextSibling()
+        continue
       }
-      return ast.WalkSkipChildren, nil
     }
 
-    if isParagraph(node) {
-      paragraphBlock := convertParagraph(node.(*ast.Paragraph), source)
-      if paragraphBlock != nil {
-        blocks = append(blocks, paragraphBlock)
+    converted, err := c.convertBlock(child, source)
+    if err != nil {
+      return nil, err
+    }
+    blocks = append(blocks, converted...)
+    child = child.NextSibling()
+  }
+
+  return blocks, nil
+}
+
+// convertBlock converts a block-level node to Notion blocks.
+// Nodes that are not handled are walked into, so that their children are converted instead.
+func (c *Converter) convertBlock(node ast.Node, source []byte) ([]notionapi.Block, error) {
+  converted, ok, err := c.convertNode(node, source)
+  if err != nil {
+    return nil, err
+  }
+  if !ok {
+    return c.convertBlocks(node, source)
+  }
+  return converted, nil
+}
+
+// convertNode converts a single block-level node to Notion blocks.
+// It reports false when the node is not handled, so that its children are walked instead.
+func (c *Converter) convertNode(node ast.Node, source []byte) ([]notionapi.Block, bool, error) {
+  // Handle code blocks
+  if isCodeBlock(node) {
+    codeBlock, err := c.convertFencedCodeBlock(node.(*ast.FencedCodeBlock), source)
+    if err != nil {
+      return nil, true, err
+    }
+    if codeBlock != nil {
+      return []notionapi.Block{codeBlock}, true, nil
+    }
+    return nil, true, nil
+  }
+
+  if isDeepHeading(node) && c.DeepHeadingStyle == DeepHeadingParagraph {
+    paragraphBlock := convertDeepHeadingParagraph(node.(*ast.Heading), source)
+    if paragraphBlock != nil {
+      return []notionapi.Block{paragraphBlock}, true, nil
+    }
+    return nil, true, nil
+  }
+
+  if isHeading(node) {
+    block := convertHeading(node.(*ast.Heading), source, c.H1Color, c.H2Color, c.H3Color)
+    if block != nil {
+      return []notionapi.Block{block}, true, nil
+    }
+    return nil, true, nil
+  }
+
+  if isList(node) {
+    listBlocks, err := c.convertList(node.(*ast.List), source)
+    return listBlocks, true, err
+  }
+
+  if isBlockquote(node) {
+    if kind := alertKind(node.(*ast.Blockquote), source); kind != "" {
+      if style, ok := c.alertStyles()[kind]; ok {
+        stripAlertMarker(node.(*ast.Blockquote))
+        children, err := c.convertBlocks(node, source)
+        if err != nil {
+          return nil, true, err
+        }
+        return []notionapi.Block{convertAlert(kind, style, children)}, true, nil
       }
-      return ast.WalkSkipChildren, nil
     }
 
-    if isTable(node) {
-      tableBlock := convertTable(node.(*east.Table), source)
-      if tableBlock != nil {
-        blocks = append(blocks, tableBlock)
+    children, err := c.convertSiblings(quoteBodyStart(node.(*ast.Blockquote)), source)
+    if err != nil {
+      return nil, true, err
+    }
+
+    // Images in the quote text are split out as the first children of the quote
+    first := node.FirstChild()
+    if isParagraph(first) && hasImage(first) {
+      richText, imageBlocks, err := c.convertTextWithImages(first, source)
+      if err != nil {
+        return nil, true, err
       }
-      return ast.WalkSkipChildren, nil
+      if quoteBlock := convertBlockquote(node.(*ast.Blockquote), source, append(imageBlocks, children...)); quoteBlock != nil {
+        quoteBlock.Quote.RichText = richText
+        return []notionapi.Block{quoteBlock}, true, nil
+      }
+      return nil, true, nil
     }
 
-    // Handle other node types here
-    // Note: Image nodes are not implemented yet
+    quoteBlock := convertBlockquote(node.(*ast.Blockquote), source, children)
+    if quoteBlock != nil {
+      return []notionapi.Block{quoteBlock}, true, nil
+    }
+    return nil, true, nil
+  }
 
-    return ast.WalkContinue, nil
-  })
+  // Images are split out of their paragraph as their own blocks
+  if isParagraph(node) && hasImage(node) {
+    blocks, err := c.convertParagraphWithImages(node.(*ast.Paragraph), source)
+    return blocks, true, err
+  }
 
-  return blocks, nil
+  if isParagraph(node) {
+    paragraphBlock := convertParagraph(node.(*ast.Paragraph), source)
+    if paragraphBlock != nil {
+      return []notionapi.Block{paragraphBlock}, true, nil
+    }
+    return nil, true, nil
+  }
+
+  if isTable(node) {
+    tableBlock := convertTable(node.(*east.Table), source)
+    if tableBlock != nil {
+      return []notionapi.Block{tableBlock}, true, nil
+    }
+    return nil, true, nil
+  }
+
+  if isIndentedCodeBlock(node) {
+    codeBlock := convertIndentedCodeBlock(node.(*ast.CodeBlock), source)
+    if codeBlock != nil {
+      return []notionapi.Block{codeBlock}, true, nil
+    }
+    return nil, true, nil
+  }
+
+  if isHTMLBlock(node) {
+    htmlBlocks, err := c.convertHTMLBlock(node.(*ast.HTMLBlock), source)
+    return htmlBlocks, true, err
+  }
+
+  if isThematicBreak(node) {
+    return []notionapi.Block{convertThematicBreak(node.(*ast.ThematicBreak))}, true, nil
+  }
+
+  if isFootnoteList(node) {
+    blocks, err := c.convertFootnoteList(node.(*east.FootnoteList), source)
+    return blocks, true, err
+  }
+
+  if isMathBlock(node) {
+    if equationBlock := convertMathBlock(node.(*mathBlockNode), source); equationBlock != nil {
+      return []notionapi.Block{equationBlock}, true, nil
+    }
+    return nil, true, nil
+  }
+
+  // Handle other node types here
+
+  return nil, false, nil
 }
 
 // convertChildNodesToRichText converts the child nodes of a given AST node to Notion rich text blocks.
@@ -108,33 +325,16 @@ func convertChildNodesToRichText(node ast.Node, source []byte) []notionapi.RichT
 
   var blocks []notionapi.RichText
   for child := node.FirstChild(); child != nil; child = child.NextSibling() {
-    // Handle different types of inline elements
-    if isLink(child) {
-      // Convert link node
-      linkRichText := convertLink(child.(*ast.Link), source)
-      if linkRichText != nil {
-        blocks = append(blocks, linkRichText...)
-      }
-    } else if isEmphasis(child) || isStrong(child) || isCodeSpan(child) {
-      // Convert style nodes (emphasis, strong, code span)
-      styleRichText := convertStyle(child, source)
-      if styleRichText != nil {
-        blocks = append(blocks, styleRichText...)
-      }
-    } else if text, ok := child.(*ast.Text); ok {
-      // Convert plain text
-      content := string(text.Segment.Value(source))
-      if content != "" {
-        blocks = append(blocks, chunk.RichText(content, nil)...)
-      }
-    } else {
-      // Recursively process other node types
-      childBlocks := convertChildNodesToRichText(child, source)
-      if childBlocks != nil {
-        blocks = append(blocks, childBlocks...)
-      }
+    childBlocks := convertInlineNode(child, source)
+    if childBlocks != nil {
+      blocks = append(blocks, childBlocks...)
     }
   }
 
   return blocks
 }
+
+// convertInlineNode converts a single inline node to Notion rich text blocks.
+func convertInlineNode(node ast.Node, source []byte) []notionapi.RichText {
+  return convertStyledInline(node, source, inlineStyle{})
+}
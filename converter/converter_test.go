@@ -0,0 +1,52 @@
+package converter
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jomei/notionapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/text"
+)
+
+// parseMarkdown parses markdown source into a goldmark document for tests.
+func parseMarkdown(source []byte) ast.Node {
+	return newMarkdown().Parser().Parse(text.NewReader(source))
+}
+
+// convertMarkdown writes markdown to a temporary file and converts it.
+func convertMarkdown(t *testing.T, c *Converter, markdown string) []notionapi.Block {
+	t.Helper()
+
+	c.MarkdownFilePath = filepath.Join(t.TempDir(), "test.md")
+	require.NoError(t, os.WriteFile(c.MarkdownFilePath, []byte(markdown), 0o644))
+
+	blocks, err := Convert(c)
+	require.NoError(t, err)
+	return blocks
+}
+
+func TestConvert(t *testing.T) {
+	t.Run("returns error for missing file", func(t *testing.T) {
+		_, err := Convert(&Converter{MarkdownFilePath: filepath.Join(t.TempDir(), "missing.md")})
+		assert.Error(t, err)
+	})
+
+	t.Run("converts blocks in document order", func(t *testing.T) {
+		blocks := convertMarkdown(t, &Converter{}, "# Title\n\nText before\n\n![logo](https://example.com/logo.png)\n\n- item\n")
+
+		var types []notionapi.BlockType
+		for _, b := range blocks {
+			types = append(types, b.GetType())
+		}
+		assert.Equal(t, []notionapi.BlockType{
+			notionapi.BlockTypeHeading1,
+			notionapi.BlockTypeParagraph,
+			notionapi.BlockTypeImage,
+			notionapi.BlockTypeBulletedListItem,
+		}, types)
+	})
+}
@@ -0,0 +1,32 @@
+package converter
+
+import (
+	"github.com/jomei/notionapi"
+	"github.com/yuin/goldmark/ast"
+)
+
+// isThematicBreak checks if a node is a thematic break (horizontal rule).
+func isThematicBreak(node ast.Node) bool {
+	_, ok := node.(*ast.ThematicBreak)
+	return ok
+}
+
+// convertThematicBreak converts a thematic break node to a Notion divider block.
+func convertThematicBreak(node *ast.ThematicBreak) *notionapi.DividerBlock {
+	if node == nil {
+		return nil
+	}
+
+	return newDividerBlock()
+}
+
+// newDividerBlock creates a Notion divider block.
+func newDividerBlock() *notionapi.DividerBlock {
+	return &notionapi.DividerBlock{
+		BasicBlock: notionapi.BasicBlock{
+			Object: notionapi.ObjectTypeBlock,
+			Type:   notionapi.BlockTypeDivider,
+		},
+		Divider: notionapi.Divider{},
+	}
+}
@@ -0,0 +1,45 @@
+package converter
+
+import (
+	"testing"
+
+	"github.com/jomei/notionapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/yuin/goldmark/ast"
+)
+
+func TestIsThematicBreak(t *testing.T) {
+	t.Run("is a thematic break node", func(t *testing.T) {
+		node := &ast.ThematicBreak{}
+		assert.True(t, isThematicBreak(node))
+	})
+
+	t.Run("not a thematic break node", func(t *testing.T) {
+		node := &ast.Paragraph{}
+		assert.False(t, isThematicBreak(node))
+	})
+}
+
+func TestConvertThematicBreak(t *testing.T) {
+	t.Run("handles nil thematic break", func(t *testing.T) {
+		assert.Nil(t, convertThematicBreak(nil))
+	})
+
+	t.Run("converts thematic break to divider", func(t *testing.T) {
+		result := convertThematicBreak(ast.NewThematicBreak())
+
+		assert.NotNil(t, result)
+		assert.Equal(t, notionapi.BlockTypeDivider, result.Type)
+		assert.Equal(t, notionapi.ObjectTypeBlock, result.Object)
+	})
+
+	t.Run("separates sections in a document", func(t *testing.T) {
+		blocks := convertMarkdown(t, &Converter{}, "Phase one\n\n---\n\nPhase two\n\n***\n")
+
+		assert.Len(t, blocks, 4)
+		assert.Equal(t, notionapi.BlockTypeParagraph, blocks[0].GetType())
+		assert.Equal(t, notionapi.BlockTypeDivider, blocks[1].GetType())
+		assert.Equal(t, notionapi.BlockTypeParagraph, blocks[2].GetType())
+		assert.Equal(t, notionapi.BlockTypeDivider, blocks[3].GetType())
+	})
+}
@@ -0,0 +1,62 @@
+package converter
+
+import (
+	"strings"
+
+	"github.com/jomei/notionapi"
+	"github.com/yuin/goldmark/ast"
+)
+
+// isMathBlock checks if a node is a display math block.
+func isMathBlock(node ast.Node) bool {
+	_, ok := node.(*mathBlockNode)
+	return ok
+}
+
+// convertMathBlock converts a display math node to a Notion equation block.
+func convertMathBlock(node *mathBlockNode, source []byte) *notionapi.EquationBlock {
+	if node == nil {
+		return nil
+	}
+
+	lines := make([]string, node.Lines().Len())
+	for i := range lines {
+		segment := node.Lines().At(i)
+		lines[i] = strings.TrimRight(string(segment.Value(source)), "\r\n")
+	}
+
+	expression := strings.TrimSpace(strings.Join(lines, "\n"))
+	if expression == "" {
+		return nil
+	}
+
+	return &notionapi.EquationBlock{
+		BasicBlock: notionapi.BasicBlock{
+			Object: notionapi.ObjectTypeBlock,
+			Type:   notionapi.BlockTypeEquation,
+		},
+		Equation: notionapi.Equation{
+			Expression: expression,
+		},
+	}
+}
+
+// newEquationRichText creates an inline equation for an expression with the given style.
+// Equations can't be links, so only the annotations of the style are kept.
+func newEquationRichText(expression string, style inlineStyle) []notionapi.RichText {
+	expression = strings.TrimSpace(expression)
+	if expression == "" {
+		return nil
+	}
+
+	richText := notionapi.RichText{
+		Type:      notionapi.ObjectType("equation"),
+		Equation:  &notionapi.Equation{Expression: expression},
+		PlainText: expression,
+	}
+	if style.annotations != (notionapi.Annotations{}) {
+		a := style.annotations
+		richText.Annotations = &a
+	}
+	return []notionapi.RichText{richText}
+}
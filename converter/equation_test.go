@@ -0,0 +1,85 @@
+package converter
+
+import (
+	"testing"
+
+	"github.com/jomei/notionapi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertMathBlock(t *testing.T) {
+	t.Run("converts display math to an equation block", func(t *testing.T) {
+		blocks := convertMarkdown(t, &Converter{}, "Before\n\n$$\n\\sum_{i=1}^n i\n= \\frac{n(n+1)}{2}\n$$\n\nAfter\n")
+
+		assert.Len(t, blocks, 3)
+		equation, ok := blocks[1].(*notionapi.EquationBlock)
+		assert.True(t, ok)
+		assert.Equal(t, notionapi.BlockTypeEquation, equation.Type)
+		assert.Equal(t, "\\sum_{i=1}^n i\n= \\frac{n(n+1)}{2}", equation.Equation.Expression)
+		assert.Equal(t, "After", blocks[2].GetRichTextString())
+	})
+
+	t.Run("converts single line display math", func(t *testing.T) {
+		blocks := convertMarkdown(t, &Converter{}, "$$ E = mc^2 $$\n")
+
+		assert.Len(t, blocks, 1)
+		assert.Equal(t, "E = mc^2", blocks[0].(*notionapi.EquationBlock).Equation.Expression)
+	})
+
+	t.Run("keeps content on the fence lines", func(t *testing.T) {
+		blocks := convertMarkdown(t, &Converter{}, "$$a +\nb$$\n")
+
+		assert.Len(t, blocks, 1)
+		assert.Equal(t, "a +\nb", blocks[0].(*notionapi.EquationBlock).Equation.Expression)
+	})
+
+	t.Run("interrupts a paragraph", func(t *testing.T) {
+		blocks := convertMarkdown(t, &Converter{}, "Text\n$$\nx\n$$\n")
+
+		assert.Len(t, blocks, 2)
+		assert.Equal(t, "Text", blocks[0].GetRichTextString())
+		assert.Equal(t, "x", blocks[1].(*notionapi.EquationBlock).Equation.Expression)
+	})
+
+	t.Run("leaves an unclosed $$ as text", func(t *testing.T) {
+		blocks := convertMarkdown(t, &Converter{}, "$$\nx^2\n\n# Heading\n\nSome text\n")
+
+		assert.Len(t, blocks, 3)
+		assert.Equal(t, notionapi.BlockTypeParagraph, blocks[0].GetType())
+		assert.Equal(t, "$$ x^2", blocks[0].GetRichTextString())
+		assert.Equal(t, "Heading", blocks[1].GetRichTextString())
+		assert.Equal(t, "Some text", blocks[2].GetRichTextString())
+	})
+}
+
+func TestConvertInlineMath(t *testing.T) {
+	t.Run("mixes inline equations with text", func(t *testing.T) {
+		src := []byte("Euler: $e^{i\\pi} + 1 = 0$ holds.")
+		richText := convertChildNodesToRichText(parseMarkdown(src).FirstChild(), src)
+
+		assert.Len(t, richText, 3)
+		assert.Equal(t, "Euler: ", richText[0].PlainText)
+		assert.Equal(t, notionapi.ObjectType("equation"), richText[1].Type)
+		assert.Nil(t, richText[1].Text)
+		assert.Equal(t, "e^{i\\pi} + 1 = 0", richText[1].Equation.Expression)
+		assert.Equal(t, " holds.", richText[2].PlainText)
+	})
+
+	t.Run("keeps annotations of enclosing styles", func(t *testing.T) {
+		src := []byte("**$x$**")
+		richText := convertChildNodesToRichText(parseMarkdown(src).FirstChild(), src)
+
+		assert.Len(t, richText, 1)
+		assert.Equal(t, "x", richText[0].Equation.Expression)
+		assert.True(t, richText[0].Annotations.Bold)
+	})
+
+	t.Run("keeps dollar amounts and escaped dollars as text", func(t *testing.T) {
+		for _, markdown := range []string{"It costs $5 and $10.", "\\$x$ and $ y $", "$$"} {
+			src := []byte(markdown)
+			for _, richText := range convertChildNodesToRichText(parseMarkdown(src).FirstChild(), src) {
+				assert.Equal(t, notionapi.ObjectTypeText, richText.Type, markdown)
+			}
+		}
+	})
+}
@@ -0,0 +1,339 @@
+package converter
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// underlineNode represents underlined text written as ++text++ or <u>text</u>.
+type underlineNode struct {
+	ast.BaseInline
+}
+
+// kindUnderline is a NodeKind of the underlineNode.
+var kindUnderline = ast.NewNodeKind("Underline")
+
+// Kind implements Node.Kind.
+func (n *underlineNode) Kind() ast.NodeKind {
+	return kindUnderline
+}
+
+// Dump implements Node.Dump.
+func (n *underlineNode) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, nil, nil)
+}
+
+// highlightNode represents highlighted text written as ==text==.
+type highlightNode struct {
+	ast.BaseInline
+}
+
+// kindHighlight is a NodeKind of the highlightNode.
+var kindHighlight = ast.NewNodeKind("Highlight")
+
+// Kind implements Node.Kind.
+func (n *highlightNode) Kind() ast.NodeKind {
+	return kindHighlight
+}
+
+// Dump implements Node.Dump.
+func (n *highlightNode) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, nil, nil)
+}
+
+// mathBlockNode represents display math enclosed by $$ lines.
+type mathBlockNode struct {
+	ast.BaseBlock
+	closed bool
+}
+
+// kindMathBlock is a NodeKind of the mathBlockNode.
+var kindMathBlock = ast.NewNodeKind("MathBlock")
+
+// Kind implements Node.Kind.
+func (n *mathBlockNode) Kind() ast.NodeKind {
+	return kindMathBlock
+}
+
+// IsRaw implements Node.IsRaw.
+func (n *mathBlockNode) IsRaw() bool {
+	return true
+}
+
+// Dump implements Node.Dump.
+func (n *mathBlockNode) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, nil, nil)
+}
+
+// mathInlineNode represents inline math written as $x$.
+type mathInlineNode struct {
+	ast.BaseInline
+	Segment text.Segment
+}
+
+// kindMathInline is a NodeKind of the mathInlineNode.
+var kindMathInline = ast.NewNodeKind("MathInline")
+
+// Kind implements Node.Kind.
+func (n *mathInlineNode) Kind() ast.NodeKind {
+	return kindMathInline
+}
+
+// Dump implements Node.Dump.
+func (n *mathInlineNode) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, map[string]string{"Value": string(n.Segment.Value(source))}, nil)
+}
+
+// doubleDelimiterParser parses inline spans enclosed by a doubled character, like ==text==.
+type doubleDelimiterParser struct {
+	char    byte
+	newNode func() ast.Node
+}
+
+// IsDelimiter implements parser.DelimiterProcessor.IsDelimiter.
+func (p *doubleDelimiterParser) IsDelimiter(b byte) bool {
+	return b == p.char
+}
+
+// CanOpenCloser implements parser.DelimiterProcessor.CanOpenCloser.
+func (p *doubleDelimiterParser) CanOpenCloser(opener, closer *parser.Delimiter) bool {
+	return opener.Char == closer.Char
+}
+
+// OnMatch implements parser.DelimiterProcessor.OnMatch.
+func (p *doubleDelimiterParser) OnMatch(consumes int) ast.Node {
+	return p.newNode()
+}
+
+// Trigger implements parser.InlineParser.Trigger.
+func (p *doubleDelimiterParser) Trigger() []byte {
+	return []byte{p.char}
+}
+
+// Parse implements parser.InlineParser.Parse.
+func (p *doubleDelimiterParser) Parse(parent ast.Node, block text.Reader, pc parser.Context) ast.Node {
+	before := block.PrecendingCharacter()
+	line, segment := block.PeekLine()
+	node := parser.ScanDelimiter(line, before, 2, p)
+	if node == nil || node.OriginalLength != 2 || before == rune(p.char) {
+		return nil
+	}
+
+	node.Segment = segment.WithStop(segment.Start + node.OriginalLength)
+	block.Advance(node.OriginalLength)
+	pc.PushDelimiter(node)
+	return node
+}
+
+// CloseBlock implements parser.InlineParser.CloseBlock.
+func (p *doubleDelimiterParser) CloseBlock(parent ast.Node, pc parser.Context) {
+	// nothing to do
+}
+
+// mathBlockParser parses display math starting with a $$ line and ending with a line closed by $$.
+// A single line like $$ x^2 $$ is a whole block. A $$ line without a closing line before the next
+// blank line is left to the paragraph parser, so a stray $$ doesn't swallow the rest of the document.
+type mathBlockParser struct{}
+
+// Trigger implements parser.BlockParser.Trigger.
+func (p *mathBlockParser) Trigger() []byte {
+	return []byte{'$'}
+}
+
+// Open implements parser.BlockParser.Open.
+func (p *mathBlockParser) Open(parent ast.Node, reader text.Reader, pc parser.Context) (ast.Node, parser.State) {
+	line, segment := reader.PeekLine()
+	pos := pc.BlockOffset()
+	if pos < 0 || !bytes.HasPrefix(line[pos:], []byte("$$")) {
+		return nil, parser.NoChildren
+	}
+
+	node := &mathBlockNode{}
+	start := segment.Start - segment.Padding + pos + 2
+	content := bytes.TrimRight(line[pos+2:], " \t\r\n")
+	if bytes.HasSuffix(content, []byte("$$")) {
+		content = content[:len(content)-2]
+		node.closed = true
+	}
+	if !node.closed && !hasMathBlockCloser(reader.Source()[segment.Stop:]) {
+		return nil, parser.NoChildren
+	}
+	if !util.IsBlank(content) {
+		node.Lines().Append(text.NewSegment(start, start+len(content)))
+	}
+
+	reader.AdvanceToEOL()
+	return node, parser.NoChildren
+}
+
+// Continue implements parser.BlockParser.Continue.
+func (p *mathBlockParser) Continue(node ast.Node, reader text.Reader, pc parser.Context) parser.State {
+	if node.(*mathBlockNode).closed {
+		return parser.Close
+	}
+
+	line, segment := reader.PeekLine()
+	if util.IsBlank(line) {
+		return parser.Close
+	}
+	content := bytes.TrimRight(segment.Value(reader.Source()), " \t\r\n")
+	closed := bytes.HasSuffix(content, []byte("$$"))
+	if closed {
+		content = content[:len(content)-2]
+	}
+	if !closed || !util.IsBlank(content) {
+		node.Lines().Append(segment.WithStop(segment.Start + len(content)))
+	}
+
+	reader.AdvanceToEOL()
+	if closed {
+		return parser.Close
+	}
+	return parser.Continue | parser.NoChildren
+}
+
+// Close implements parser.BlockParser.Close.
+func (p *mathBlockParser) Close(node ast.Node, reader text.Reader, pc parser.Context) {
+	// nothing to do
+}
+
+// CanInterruptParagraph implements parser.BlockParser.CanInterruptParagraph.
+func (p *mathBlockParser) CanInterruptParagraph() bool {
+	return true
+}
+
+// CanAcceptIndentedLine implements parser.BlockParser.CanAcceptIndentedLine.
+func (p *mathBlockParser) CanAcceptIndentedLine() bool {
+	return false
+}
+
+// hasMathBlockCloser checks if a line closed by $$ follows before the next blank line.
+func hasMathBlockCloser(rest []byte) bool {
+	for len(rest) > 0 {
+		line := rest
+		if i := bytes.IndexByte(rest, '\n'); i >= 0 {
+			line, rest = rest[:i], rest[i+1:]
+		} else {
+			rest = nil
+		}
+
+		if util.IsBlank(line) {
+			return false
+		}
+		if bytes.HasSuffix(bytes.TrimRight(line, " \t\r"), []byte("$$")) {
+			return true
+		}
+	}
+	return false
+}
+
+// mathInlineParser parses inline math written as $x$.
+// Like pandoc, the opening $ must be followed by a non-space and the closing $ must be preceded
+// by a non-space and not followed by a digit, so prices like "$5 and $10" stay text.
+type mathInlineParser struct{}
+
+// Trigger implements parser.InlineParser.Trigger.
+func (p *mathInlineParser) Trigger() []byte {
+	return []byte{'$'}
+}
+
+// Parse implements parser.InlineParser.Parse.
+func (p *mathInlineParser) Parse(parent ast.Node, block text.Reader, pc parser.Context) ast.Node {
+	line, segment := block.PeekLine()
+	if len(line) < 3 || line[1] == '$' || util.IsSpace(line[1]) {
+		return nil
+	}
+
+	for i := 2; i < len(line); i++ {
+		switch {
+		case line[i] == '\\':
+			i++
+		case line[i] == '$' && !util.IsSpace(line[i-1]) && (i+1 == len(line) || !util.IsNumeric(line[i+1])):
+			node := &mathInlineNode{Segment: text.NewSegment(segment.Start+1, segment.Start+i)}
+			block.Advance(i + 1)
+			return node
+		}
+	}
+	return nil
+}
+
+// htmlUnderlineTransformer wraps the nodes between inline <u> and </u> tags into an underlineNode.
+type htmlUnderlineTransformer struct{}
+
+// Transform implements parser.ASTTransformer.Transform.
+func (t *htmlUnderlineTransformer) Transform(node *ast.Document, reader text.Reader, pc parser.Context) {
+	source := reader.Source()
+
+	var openers []*ast.RawHTML
+	_ = ast.Walk(node, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if raw, ok := n.(*ast.RawHTML); ok && entering && rawHTMLIs(raw, source, "<u>") {
+			openers = append(openers, raw)
+		}
+		return ast.WalkContinue, nil
+	})
+
+	for _, opener := range openers {
+		var closer ast.Node
+		for sibling := opener.NextSibling(); sibling != nil; sibling = sibling.NextSibling() {
+			if raw, ok := sibling.(*ast.RawHTML); ok && rawHTMLIs(raw, source, "</u>") {
+				closer = raw
+				break
+			}
+		}
+		if closer == nil {
+			continue
+		}
+
+		parent := opener.Parent()
+		underline := &underlineNode{}
+		for sibling := opener.NextSibling(); sibling != closer; {
+			next := sibling.NextSibling()
+			underline.AppendChild(underline, sibling)
+			sibling = next
+		}
+		parent.ReplaceChild(parent, opener, underline)
+		parent.RemoveChild(parent, closer)
+	}
+}
+
+// rawHTMLIs checks if a raw HTML node consists of the given tag.
+func rawHTMLIs(node *ast.RawHTML, source []byte, tag string) bool {
+	return strings.EqualFold(strings.TrimSpace(string(node.Segments.Value(source))), tag)
+}
+
+// inlineStyles is a goldmark extension parsing the inline styles Notion supports beyond CommonMark.
+type inlineStyles struct{}
+
+// Extend implements goldmark.Extender.Extend.
+func (e *inlineStyles) Extend(m goldmark.Markdown) {
+	m.Parser().AddOptions(
+		parser.WithInlineParsers(
+			util.Prioritized(&doubleDelimiterParser{char: '+', newNode: func() ast.Node { return &underlineNode{} }}, 500),
+			util.Prioritized(&doubleDelimiterParser{char: '=', newNode: func() ast.Node { return &highlightNode{} }}, 500),
+		),
+		parser.WithASTTransformers(
+			util.Prioritized(&htmlUnderlineTransformer{}, 500),
+		),
+	)
+}
+
+// math is a goldmark extension parsing $$ display math and $ inline math.
+type math struct{}
+
+// Extend implements goldmark.Extender.Extend.
+func (e *math) Extend(m goldmark.Markdown) {
+	m.Parser().AddOptions(
+		parser.WithBlockParsers(
+			util.Prioritized(&mathBlockParser{}, 500),
+		),
+		parser.WithInlineParsers(
+			util.Prioritized(&mathInlineParser{}, 500),
+		),
+	)
+}
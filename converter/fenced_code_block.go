@@ -1,9 +1,18 @@
 package converter
 
 import (
+  "fmt"
+  "os"
+  "path/filepath"
+  "regexp"
+  "strconv"
+  "strings"
+  "unicode"
+
   "github.com/jomei/notionapi"
   "github.com/sioncojp/go-markdown-to-notion/chunk"
   "github.com/yuin/goldmark/ast"
+  "gopkg.in/yaml.v3"
 )
 
 // isCodeBlock checks if a node is a code block.
@@ -12,29 +21,69 @@ func isCodeBlock(node ast.Node) bool {
   return ok
 }
 
-// extractLanguage extracts the language from a code block node.
-func extractLanguage(node *ast.FencedCodeBlock, source []byte) string {
-  if node == nil {
-    return ""
+// codeInfoAttributeRegexp matches an info string attribute like title="main.go" and captures its key and value.
+var codeInfoAttributeRegexp = regexp.MustCompile(`([A-Za-z][-A-Za-z0-9_]*)=(?:"([^"]*)"|'([^']*)'|(\S+))`)
+
+// parseInfoString splits the info string of a code fence into the language and its attributes.
+// The "lang:filename" form sets the title attribute unless title= is given.
+func parseInfoString(info string) (string, map[string]string) {
+  info = strings.TrimSpace(info)
+  attributes := map[string]string{}
+
+  language := info
+  rest := ""
+  if i := strings.IndexFunc(info, unicode.IsSpace); i >= 0 {
+    language, rest = info[:i], info[i:]
+  }
+  if strings.Contains(language, "=") {
+    language, rest = "", info
+  }
+
+  for _, match := range codeInfoAttributeRegexp.FindAllStringSubmatch(rest, -1) {
+    attributes[strings.ToLower(match[1])] = match[2] + match[3] + match[4]
   }
 
-  language := string(node.Language(source))
-  valid := validateLanguage(language)
-  if !valid {
-    return ""
+  if lang, filename, ok := strings.Cut(language, ":"); ok {
+    language = lang
+    if _, exists := attributes["title"]; !exists && filename != "" {
+      attributes["title"] = filename
+    }
   }
 
-  return language
+  return language, attributes
+}
+
+// codeBlockInfo returns the language and attributes of a code block node.
+func codeBlockInfo(node *ast.FencedCodeBlock, source []byte) (string, map[string]string) {
+  if node.Info == nil {
+    return parseInfoString("")
+  }
+  return parseInfoString(string(node.Info.Segment.Value(source)))
 }
 
-func convertFencedCodeBlock(node *ast.FencedCodeBlock, source []byte) *notionapi.CodeBlock {
+// convertFencedCodeBlock converts a fenced code block node to a Notion code block.
+// A file= attribute replaces the content with the file, or the lines= range of it,
+// resolved relative to the markdown file.
+func (c *Converter) convertFencedCodeBlock(node *ast.FencedCodeBlock, source []byte) (*notionapi.CodeBlock, error) {
   if node == nil {
-    return nil
+    return nil, nil
   }
 
+  language, attributes := codeBlockInfo(node, source)
   content := string(node.Lines().Value(source))
+  if attributes["file"] != "" {
+    var err error
+    content, err = c.readCodeFile(attributes["file"], attributes["lines"])
+    if err != nil {
+      return nil, err
+    }
+    if language == "" {
+      language = languageFromFilename(attributes["file"])
+    }
+  }
+
   if content == "" {
-    return nil
+    return nil, nil
   }
 
   result := &notionapi.CodeBlock{
@@ -44,97 +93,299 @@ func convertFencedCodeBlock(node *ast.FencedCodeBlock, source []byte) *notionapi
     },
     Code: notionapi.Code{
       RichText: chunk.RichText(content, nil),
+      Language: resolveLanguage(language, c.LanguageAliases),
     },
   }
 
-  language := extractLanguage(node, source)
-  if language != "" {
-    result.Code.Language = language
+  if attributes["title"] != "" {
+    result.Code.Caption = chunk.RichText(attributes["title"], nil)
   }
 
-  return result
+  return result, nil
 }
 
-// validateLanguage checks if the code language is a valid option
-// for Notion's code block.
+// readCodeFile reads a file included by a code block, relative to the markdown file.
+// lines selects a 1-based range like "10-40", "10-" or "10"; the whole file is read when empty.
+func (c *Converter) readCodeFile(file string, lines string) (string, error) {
+  path := file
+  if !filepath.IsAbs(path) {
+    path = filepath.Join(filepath.Dir(c.MarkdownFilePath), path)
+  }
+
+  data, err := os.ReadFile(path)
+  if err != nil {
+    return "", fmt.Errorf("failed to read code file: %w", err)
+  }
+
+  content := string(data)
+  if lines == "" {
+    return content, nil
+  }
+
+  fileLines := strings.SplitAfter(content, "\n")
+  if fileLines[len(fileLines)-1] == "" {
+    fileLines = fileLines[:len(fileLines)-1]
+  }
+
+  start, end, err := parseLineRange(lines, len(fileLines))
+  if err != nil {
+    return "", fmt.Errorf("invalid lines of code file %s: %w", file, err)
+  }
+
+  return strings.Join(fileLines[start-1:end], ""), nil
+}
+
+// parseLineRange parses a 1-based line range like "10-40", "10-" or "10" of a file with count lines.
+func parseLineRange(lines string, count int) (int, int, error) {
+  from, to, isRange := strings.Cut(lines, "-")
+
+  start, err := strconv.Atoi(strings.TrimSpace(from))
+  if err != nil {
+    return 0, 0, fmt.Errorf("invalid line range %q", lines)
+  }
+
+  end := start
+  if isRange {
+    end = count
+    if to = strings.TrimSpace(to); to != "" {
+      end, err = strconv.Atoi(to)
+      if err != nil {
+        return 0, 0, fmt.Errorf("invalid line range %q", lines)
+      }
+    }
+  }
+
+  if start < 1 || end < start || end > count {
+    return 0, 0, fmt.Errorf("line range %q is out of the file with %d lines", lines, count)
+  }
+  return start, end, nil
+}
+
+// languageFromFilename infers the code fence language of a file from its extension,
+// or from its name for files like Dockerfile and Makefile.
+func languageFromFilename(file string) string {
+  name := filepath.Base(file)
+  if ext := filepath.Ext(name); ext != "" && ext != name {
+    return strings.TrimPrefix(ext, ".")
+  }
+  return name
+}
+
+// PlainTextLanguage is the language used for code blocks whose language is unknown.
+const PlainTextLanguage = "plain text"
+
+// DefaultLanguageAliases maps common code fence tags to Notion's language names.
+var DefaultLanguageAliases = map[string]string{
+  "cc":            "c++",
+  "cjs":           "javascript",
+  "console":       "shell",
+  "cpp":           "c++",
+  "cs":            "c#",
+  "csharp":        "c#",
+  "cxx":           "c++",
+  "dockerfile":    "docker",
+  "erl":           "erlang",
+  "ex":            "elixir",
+  "exs":           "elixir",
+  "fs":            "f#",
+  "fsharp":        "f#",
+  "golang":        "go",
+  "hpp":           "c++",
+  "hs":            "haskell",
+  "htm":           "html",
+  "js":            "javascript",
+  "json5":         "json",
+  "jsonc":         "json",
+  "jsx":           "javascript",
+  "kt":            "kotlin",
+  "kts":           "kotlin",
+  "make":          "makefile",
+  "md":            "markdown",
+  "mjs":           "javascript",
+  "mk":            "makefile",
+  "node":          "javascript",
+  "objc":          "objective-c",
+  "plaintext":     PlainTextLanguage,
+  "proto":         "protobuf",
+  "ps":            "powershell",
+  "ps1":           "powershell",
+  "pwsh":          "powershell",
+  "py":            "python",
+  "python3":       "python",
+  "rb":            "ruby",
+  "rs":            "rust",
+  "sh":            "shell",
+  "shell-session": "shell",
+  "terraform":     "hcl",
+  "text":          PlainTextLanguage,
+  "tf":            "hcl",
+  "ts":            "typescript",
+  "tsx":           "typescript",
+  "txt":           PlainTextLanguage,
+  "vb":            "visual basic",
+  "wasm":          "webassembly",
+  "yml":           "yaml",
+  "zsh":           "shell",
+}
+
+// validLanguages holds the language options of Notion's code block.
 //
 // https://developers.notion.com/reference/block#code
-func validateLanguage(language string) bool {
-  validLanguages := map[string]bool{
-    "abap":          true,
-    "arduino":       true,
-    "bash":          true,
-    "basic":         true,
-    "c":             true,
-    "clojure":       true,
-    "coffeescript":  true,
-    "c++":           true,
-    "c#":            true,
-    "css":           true,
-    "dart":          true,
-    "diff":          true,
-    "docker":        true,
-    "elixir":        true,
-    "elm":           true,
-    "erlang":        true,
-    "flow":          true,
-    "fortran":       true,
-    "f#":            true,
-    "gherkin":       true,
-    "glsl":          true,
-    "go":            true,
-    "graphql":       true,
-    "groovy":        true,
-    "haskell":       true,
-    "html":          true,
-    "java":          true,
-    "javascript":    true,
-    "json":          true,
-    "julia":         true,
-    "kotlin":        true,
-    "latex":         true,
-    "less":          true,
-    "lisp":          true,
-    "livescript":    true,
-    "lua":           true,
-    "makefile":      true,
-    "markdown":      true,
-    "markup":        true,
-    "matlab":        true,
-    "mermaid":       true,
-    "nix":           true,
-    "objective-c":   true,
-    "ocaml":         true,
-    "pascal":        true,
-    "perl":          true,
-    "php":           true,
-    "plain text":    true,
-    "powershell":    true,
-    "prolog":        true,
-    "protobuf":      true,
-    "python":        true,
-    "r":             true,
-    "reason":        true,
-    "ruby":          true,
-    "rust":          true,
-    "sass":          true,
-    "scala":         true,
-    "scheme":        true,
-    "scss":          true,
-    "shell":         true,
-    "sql":           true,
-    "swift":         true,
-    "typescript":    true,
-    "vb.net":        true,
-    "verilog":       true,
-    "vhdl":          true,
-    "visual basic":  true,
-    "webassembly":   true,
-    "xml":           true,
-    "yaml":          true,
-    "java/c/c++/c#": true,
-  }
+var validLanguages = map[string]bool{
+  "abap":           true,
+  "agda":           true,
+  "arduino":        true,
+  "ascii art":      true,
+  "assembly":       true,
+  "bash":           true,
+  "basic":          true,
+  "bnf":            true,
+  "c":              true,
+  "c#":             true,
+  "c++":            true,
+  "clojure":        true,
+  "coffeescript":   true,
+  "coq":            true,
+  "css":            true,
+  "dart":           true,
+  "dhall":          true,
+  "diff":           true,
+  "docker":         true,
+  "ebnf":           true,
+  "elixir":         true,
+  "elm":            true,
+  "erlang":         true,
+  "f#":             true,
+  "flow":           true,
+  "fortran":        true,
+  "gherkin":        true,
+  "glsl":           true,
+  "go":             true,
+  "graphql":        true,
+  "groovy":         true,
+  "haskell":        true,
+  "hcl":            true,
+  "html":           true,
+  "idris":          true,
+  "java":           true,
+  "javascript":     true,
+  "json":           true,
+  "julia":          true,
+  "kotlin":         true,
+  "latex":          true,
+  "less":           true,
+  "lisp":           true,
+  "livescript":     true,
+  "llvm ir":        true,
+  "lua":            true,
+  "makefile":       true,
+  "markdown":       true,
+  "markup":         true,
+  "mathematica":    true,
+  "matlab":         true,
+  "mermaid":        true,
+  "nix":            true,
+  "notion formula": true,
+  "objective-c":    true,
+  "ocaml":          true,
+  "pascal":         true,
+  "perl":           true,
+  "php":            true,
+  "plain text":     true,
+  "powershell":     true,
+  "prolog":         true,
+  "protobuf":       true,
+  "purescript":     true,
+  "python":         true,
+  "r":              true,
+  "racket":         true,
+  "reason":         true,
+  "ruby":           true,
+  "rust":           true,
+  "sass":           true,
+  "scala":          true,
+  "scheme":         true,
+  "scss":           true,
+  "shell":          true,
+  "smalltalk":      true,
+  "solidity":       true,
+  "sql":            true,
+  "swift":          true,
+  "toml":           true,
+  "typescript":     true,
+  "vb.net":         true,
+  "verilog":        true,
+  "vhdl":           true,
+  "visual basic":   true,
+  "webassembly":    true,
+  "xml":            true,
+  "yaml":           true,
+  "java/c/c++/c#":  true,
+}
 
+// validateLanguage checks if the code language is a valid option
+// for Notion's code block.
+func validateLanguage(language string) bool {
   _, ok := validLanguages[language]
   return ok
 }
+
+// resolveLanguage maps a code fence tag to a Notion language.
+// Aliases take precedence over DefaultLanguageAliases, and unknown languages fall back to plain text.
+// Aliases set on the Converter directly skip ParseLanguageAlias, so their languages are validated here.
+func resolveLanguage(language string, aliases map[string]string) string {
+  language = strings.ToLower(strings.TrimSpace(language))
+
+  if alias, ok := aliases[language]; ok {
+    alias = strings.ToLower(strings.TrimSpace(alias))
+    if validateLanguage(alias) {
+      return alias
+    }
+    return PlainTextLanguage
+  }
+  if alias, ok := DefaultLanguageAliases[language]; ok {
+    return alias
+  }
+  if validateLanguage(language) {
+    return language
+  }
+  return PlainTextLanguage
+}
+
+// ParseLanguageAlias parses a language alias option like "tpl=html".
+func ParseLanguageAlias(option string) (string, string, error) {
+  alias, language, ok := strings.Cut(option, "=")
+  if !ok || strings.TrimSpace(alias) == "" {
+    return "", "", fmt.Errorf("invalid language alias %q: expected ALIAS=LANGUAGE", option)
+  }
+
+  alias = strings.ToLower(strings.TrimSpace(alias))
+  language = strings.ToLower(strings.TrimSpace(language))
+  if !validateLanguage(language) {
+    return "", "", fmt.Errorf("invalid language alias %q: %q is not a Notion language", option, language)
+  }
+  return alias, language, nil
+}
+
+// LoadLanguageAliases loads language aliases from a YAML or JSON file mapping fence tags to Notion languages.
+func LoadLanguageAliases(path string) (map[string]string, error) {
+  data, err := os.ReadFile(path)
+  if err != nil {
+    return nil, fmt.Errorf("failed to read language aliases file: %w", err)
+  }
+
+  var raw map[string]string
+  if err := yaml.Unmarshal(data, &raw); err != nil {
+    return nil, fmt.Errorf("failed to parse language aliases file: %w", err)
+  }
+
+  aliases := make(map[string]string, len(raw))
+  for alias, language := range raw {
+    alias, language, err := ParseLanguageAlias(alias + "=" + language)
+    if err != nil {
+      return nil, err
+    }
+    aliases[alias] = language
+  }
+  return aliases, nil
+}
@@ -1,11 +1,14 @@
 package converter
 
 import (
+  "os"
+  "path/filepath"
   "testing"
 
   "github.com/jomei/notionapi"
   "github.com/sioncojp/go-markdown-to-notion/chunk"
   "github.com/stretchr/testify/assert"
+  "github.com/stretchr/testify/require"
   "github.com/yuin/goldmark/ast"
   "github.com/yuin/goldmark/text"
 )
@@ -28,28 +31,27 @@ func TestConvertFencedCodeBlock(t *testing.T) {
     lines.Append(text.NewSegment(6, 33)) // "fmt.Println(\"Hello, World!\")"の部分
     codeBlockNode.SetLines(lines)
 
-    language := extractLanguage(codeBlockNode, source)
-    assert.Equal(t, "go", language, "Expected language to be 'go'")
-
     expected := notionapi.CodeBlock{
       BasicBlock: notionapi.BasicBlock{
         Type: notionapi.BlockTypeCode,
       },
       Code: notionapi.Code{
         RichText: chunk.RichText("fmt.Println(\"Hello, World!\")", nil),
-        Language: language,
+        Language: "go",
       },
     }
 
     // execute the function
-    codeBlock := convertFencedCodeBlock(codeBlockNode, source)
+    codeBlock, err := (&Converter{}).convertFencedCodeBlock(codeBlockNode, source)
+    assert.NoError(t, err)
     assert.NotNil(t, codeBlock, "Expected code block to not be nil")
     assert.Equal(t, expected.Code.Language, codeBlock.Code.Language, "Expected language to match")
     assert.Equal(t, len(expected.Code.RichText), len(codeBlock.Code.RichText), "Expected rich text length to match")
   })
 
   t.Run("handles nil code block", func(t *testing.T) {
-    codeBlock := convertFencedCodeBlock(nil, nil)
+    codeBlock, err := (&Converter{}).convertFencedCodeBlock(nil, nil)
+    assert.NoError(t, err)
     assert.Nil(t, codeBlock, "Expected code block to be nil for nil code block")
   })
 
@@ -64,9 +66,10 @@ func TestConvertFencedCodeBlock(t *testing.T) {
     lines.Append(text.NewSegment(4, 31)) // "fmt.Println(\"Hello, World!\")"の部分
     codeBlockNode.SetLines(lines)
 
-    codeBlock := convertFencedCodeBlock(codeBlockNode, source)
+    codeBlock, err := (&Converter{}).convertFencedCodeBlock(codeBlockNode, source)
+    assert.NoError(t, err)
     assert.NotNil(t, codeBlock, "Expected code block to not be nil")
-    assert.Equal(t, "", codeBlock.Code.Language, "Expected language to be empty")
+    assert.Equal(t, PlainTextLanguage, codeBlock.Code.Language, "Expected missing language to fall back to plain text")
   })
 
   t.Run("handles code block with empty content", func(t *testing.T) {
@@ -82,7 +85,8 @@ func TestConvertFencedCodeBlock(t *testing.T) {
     lines := text.NewSegments()
     codeBlockNode.SetLines(lines)
 
-    codeBlock := convertFencedCodeBlock(codeBlockNode, source)
+    codeBlock, err := (&Converter{}).convertFencedCodeBlock(codeBlockNode, source)
+    assert.NoError(t, err)
     assert.Nil(t, codeBlock, "Expected code block to be nil for empty content")
   })
 
@@ -100,11 +104,215 @@ func TestConvertFencedCodeBlock(t *testing.T) {
     lines.Append(text.NewSegment(19, 46)) // "fmt.Println(\"Hello, World!\")"の部分
     codeBlockNode.SetLines(lines)
 
-    language := extractLanguage(codeBlockNode, source)
-    assert.Equal(t, "", language, "Expected language to be empty for invalid language")
-
-    codeBlock := convertFencedCodeBlock(codeBlockNode, source)
+    codeBlock, err := (&Converter{}).convertFencedCodeBlock(codeBlockNode, source)
+    assert.NoError(t, err)
     assert.NotNil(t, codeBlock, "Expected code block to not be nil")
-    assert.Equal(t, "", codeBlock.Code.Language, "Expected language to be empty for invalid language")
+    assert.Equal(t, PlainTextLanguage, codeBlock.Code.Language, "Expected invalid language to fall back to plain text")
+  })
+}
+
+func TestResolveLanguage(t *testing.T) {
+  t.Run("maps common aliases to Notion languages", func(t *testing.T) {
+    assert.Equal(t, "shell", resolveLanguage("sh", nil))
+    assert.Equal(t, "javascript", resolveLanguage("js", nil))
+    assert.Equal(t, "typescript", resolveLanguage("ts", nil))
+    assert.Equal(t, "yaml", resolveLanguage("yml", nil))
+    assert.Equal(t, "go", resolveLanguage("golang", nil))
+    assert.Equal(t, "python", resolveLanguage("py", nil))
+    assert.Equal(t, "docker", resolveLanguage("Dockerfile", nil))
+    assert.Equal(t, "hcl", resolveLanguage("tf", nil))
+    assert.Equal(t, "json", resolveLanguage("jsonc", nil))
+    assert.Equal(t, "c++", resolveLanguage("cpp", nil))
+  })
+
+  t.Run("keeps Notion languages", func(t *testing.T) {
+    assert.Equal(t, "go", resolveLanguage("go", nil))
+    assert.Equal(t, "c#", resolveLanguage("C#", nil))
+  })
+
+  t.Run("prefers user aliases", func(t *testing.T) {
+    aliases := map[string]string{"tpl": "html", "sh": "bash"}
+    assert.Equal(t, "html", resolveLanguage("tpl", aliases))
+    assert.Equal(t, "bash", resolveLanguage("sh", aliases))
+  })
+
+  t.Run("validates user aliases", func(t *testing.T) {
+    aliases := map[string]string{"tpl": "HTML", "sh": "not-a-language"}
+    assert.Equal(t, "html", resolveLanguage("tpl", aliases))
+    assert.Equal(t, PlainTextLanguage, resolveLanguage("sh", aliases))
+  })
+
+  t.Run("falls back to plain text", func(t *testing.T) {
+    assert.Equal(t, PlainTextLanguage, resolveLanguage("", nil))
+    assert.Equal(t, PlainTextLanguage, resolveLanguage("unknown", nil))
+  })
+
+  t.Run("uses configured aliases in conversion", func(t *testing.T) {
+    blocks := convertMarkdown(t, &Converter{LanguageAliases: map[string]string{"tpl": "html"}}, "```tpl\n<p>{{ .Title }}</p>\n```\n")
+
+    assert.Len(t, blocks, 1)
+    assert.Equal(t, "html", blocks[0].(*notionapi.CodeBlock).Code.Language)
+  })
+}
+
+func TestParseLanguageAlias(t *testing.T) {
+  t.Run("parses alias and language", func(t *testing.T) {
+    alias, language, err := ParseLanguageAlias("TPL=HTML")
+    assert.NoError(t, err)
+    assert.Equal(t, "tpl", alias)
+    assert.Equal(t, "html", language)
   })
+
+  t.Run("returns error for invalid option", func(t *testing.T) {
+    _, _, err := ParseLanguageAlias("tpl")
+    assert.Error(t, err)
+
+    _, _, err = ParseLanguageAlias("tpl=unknown")
+    assert.Error(t, err)
+  })
+}
+
+func TestLoadLanguageAliases(t *testing.T) {
+  t.Run("loads aliases from a YAML file", func(t *testing.T) {
+    path := filepath.Join(t.TempDir(), "languages.yml")
+    assert.NoError(t, os.WriteFile(path, []byte("tpl: html\nJenkinsfile: groovy\n"), 0o644))
+
+    aliases, err := LoadLanguageAliases(path)
+    assert.NoError(t, err)
+    assert.Equal(t, map[string]string{"tpl": "html", "jenkinsfile": "groovy"}, aliases)
+  })
+
+  t.Run("returns error for unknown language", func(t *testing.T) {
+    path := filepath.Join(t.TempDir(), "languages.yml")
+    assert.NoError(t, os.WriteFile(path, []byte("tpl: unknown\n"), 0o644))
+
+    _, err := LoadLanguageAliases(path)
+    assert.Error(t, err)
+  })
+}
+
+func TestParseInfoString(t *testing.T) {
+  t.Run("parses language only", func(t *testing.T) {
+    language, attributes := parseInfoString("go")
+    assert.Equal(t, "go", language)
+    assert.Empty(t, attributes)
+  })
+
+  t.Run("parses language and attributes", func(t *testing.T) {
+    language, attributes := parseInfoString(`go title="main.go" lines=1-3 name='cmd'`)
+    assert.Equal(t, "go", language)
+    assert.Equal(t, map[string]string{"title": "main.go", "lines": "1-3", "name": "cmd"}, attributes)
+  })
+
+  t.Run("parses language and filename", func(t *testing.T) {
+    language, attributes := parseInfoString("yaml:deploy.yaml")
+    assert.Equal(t, "yaml", language)
+    assert.Equal(t, map[string]string{"title": "deploy.yaml"}, attributes)
+  })
+
+  t.Run("prefers title attribute over filename", func(t *testing.T) {
+    _, attributes := parseInfoString(`yaml:deploy.yaml title="Deployment"`)
+    assert.Equal(t, "Deployment", attributes["title"])
+  })
+
+  t.Run("parses attributes without language", func(t *testing.T) {
+    language, attributes := parseInfoString(`title="notes.txt"`)
+    assert.Equal(t, "", language)
+    assert.Equal(t, "notes.txt", attributes["title"])
+  })
+}
+
+func TestConvertCodeBlockCaption(t *testing.T) {
+  t.Run("sets caption from title attribute", func(t *testing.T) {
+    blocks := convertMarkdown(t, &Converter{}, "```go title=\"main.go\"\npackage main\n```\n")
+
+    assert.Len(t, blocks, 1)
+    codeBlock := blocks[0].(*notionapi.CodeBlock)
+    assert.Equal(t, "go", codeBlock.Code.Language)
+    assert.Equal(t, "main.go", codeBlock.Code.Caption[0].PlainText)
+  })
+
+  t.Run("sets caption from filename", func(t *testing.T) {
+    blocks := convertMarkdown(t, &Converter{}, "```yml:deploy.yaml\nkind: Deployment\n```\n")
+
+    assert.Len(t, blocks, 1)
+    codeBlock := blocks[0].(*notionapi.CodeBlock)
+    assert.Equal(t, "yaml", codeBlock.Code.Language)
+    assert.Equal(t, "deploy.yaml", codeBlock.Code.Caption[0].PlainText)
+  })
+
+  t.Run("leaves caption empty without title", func(t *testing.T) {
+    blocks := convertMarkdown(t, &Converter{}, "```go\npackage main\n```\n")
+
+    assert.Len(t, blocks, 1)
+    assert.Empty(t, blocks[0].(*notionapi.CodeBlock).Code.Caption)
+  })
+}
+
+func TestConvertCodeBlockFile(t *testing.T) {
+  // convertWithFile converts markdown next to a cmd/main.go file with five lines.
+  convertWithFile := func(t *testing.T, markdown string) ([]notionapi.Block, error) {
+    dir := t.TempDir()
+    require.NoError(t, os.MkdirAll(filepath.Join(dir, "cmd"), 0o755))
+    require.NoError(t, os.WriteFile(filepath.Join(dir, "cmd", "main.go"), []byte("line1\nline2\nline3\nline4\nline5\n"), 0o644))
+    require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte(markdown), 0o644))
+
+    return Convert(&Converter{MarkdownFilePath: filepath.Join(dir, "README.md")})
+  }
+
+  t.Run("includes the whole file", func(t *testing.T) {
+    blocks, err := convertWithFile(t, "```go file=./cmd/main.go\n```\n")
+
+    require.NoError(t, err)
+    assert.Len(t, blocks, 1)
+    codeBlock := blocks[0].(*notionapi.CodeBlock)
+    assert.Equal(t, "go", codeBlock.Code.Language)
+    assert.Equal(t, "line1\nline2\nline3\nline4\nline5\n", codeBlock.Code.RichText[0].PlainText)
+  })
+
+  t.Run("includes a range of lines", func(t *testing.T) {
+    blocks, err := convertWithFile(t, "```go file=./cmd/main.go lines=2-4\nstale code\n```\n")
+
+    require.NoError(t, err)
+    assert.Equal(t, "line2\nline3\nline4\n", blocks[0].(*notionapi.CodeBlock).Code.RichText[0].PlainText)
+  })
+
+  t.Run("includes lines to the end of the file", func(t *testing.T) {
+    blocks, err := convertWithFile(t, "```go file=./cmd/main.go lines=4-\n```\n")
+
+    require.NoError(t, err)
+    assert.Equal(t, "line4\nline5\n", blocks[0].(*notionapi.CodeBlock).Code.RichText[0].PlainText)
+  })
+
+  t.Run("infers language from file extension", func(t *testing.T) {
+    blocks, err := convertWithFile(t, "```file=cmd/main.go lines=1\n```\n")
+
+    require.NoError(t, err)
+    codeBlock := blocks[0].(*notionapi.CodeBlock)
+    assert.Equal(t, "go", codeBlock.Code.Language)
+    assert.Equal(t, "line1\n", codeBlock.Code.RichText[0].PlainText)
+  })
+
+  t.Run("returns error for missing file", func(t *testing.T) {
+    _, err := convertWithFile(t, "```go file=./cmd/missing.go\n```\n")
+    assert.Error(t, err)
+  })
+
+  t.Run("returns error for out of range lines", func(t *testing.T) {
+    _, err := convertWithFile(t, "```go file=./cmd/main.go lines=4-10\n```\n")
+    assert.Error(t, err)
+
+    _, err = convertWithFile(t, "```go file=./cmd/main.go lines=3-2\n```\n")
+    assert.Error(t, err)
+
+    _, err = convertWithFile(t, "```go file=./cmd/main.go lines=abc\n```\n")
+    assert.Error(t, err)
+  })
+}
+
+func TestLanguageFromFilename(t *testing.T) {
+  assert.Equal(t, "go", languageFromFilename("./cmd/main.go"))
+  assert.Equal(t, "docker", resolveLanguage(languageFromFilename("build/Dockerfile"), nil))
+  assert.Equal(t, "python", resolveLanguage(languageFromFilename("script.py"), nil))
+  assert.Equal(t, PlainTextLanguage, resolveLanguage(languageFromFilename(".env"), nil))
 }
@@ -0,0 +1,91 @@
+package converter
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/jomei/notionapi"
+	"github.com/sioncojp/go-markdown-to-notion/chunk"
+	"github.com/yuin/goldmark/ast"
+	east "github.com/yuin/goldmark/extension/ast"
+)
+
+// footnotesTitle is the heading of the section collecting the footnote definitions.
+const footnotesTitle = "Footnotes"
+
+// superscriptDigits replaces digits with their superscript forms, since Notion has no superscript annotation.
+var superscriptDigits = strings.NewReplacer(
+	"0", "⁰", "1", "¹", "2", "²", "3", "³", "4", "⁴",
+	"5", "⁵", "6", "⁶", "7", "⁷", "8", "⁸", "9", "⁹",
+)
+
+// isFootnoteList checks if a node is the list of footnote definitions.
+func isFootnoteList(node ast.Node) bool {
+	_, ok := node.(*east.FootnoteList)
+	return ok
+}
+
+// footnoteMarker returns the superscript marker of a footnote reference like "¹".
+func footnoteMarker(index int) string {
+	return superscriptDigits.Replace(strconv.Itoa(index))
+}
+
+// convertFootnoteList converts the footnote definitions to a trailing section:
+// a divider, a "Footnotes" heading and a numbered list item per definition.
+func (c *Converter) convertFootnoteList(node *east.FootnoteList, source []byte) ([]notionapi.Block, error) {
+	if node == nil {
+		return nil, nil
+	}
+
+	var items []notionapi.Block
+	for child := node.FirstChild(); child != nil; child = child.NextSibling() {
+		footnote, ok := child.(*east.Footnote)
+		if !ok {
+			continue
+		}
+
+		// The first paragraph is the item text and the rest of the definition its children
+		body := footnote.FirstChild()
+		var richText []notionapi.RichText
+		if isListItemText(body) {
+			richText = convertChildNodesToRichText(body, source)
+			body = body.NextSibling()
+		}
+
+		children, err := c.convertSiblings(body, source)
+		if err != nil {
+			return nil, err
+		}
+
+		if richText == nil {
+			richText = []notionapi.RichText{}
+		}
+
+		items = append(items, &notionapi.NumberedListItemBlock{
+			BasicBlock: notionapi.BasicBlock{
+				Object: notionapi.ObjectTypeBlock,
+				Type:   notionapi.BlockTypeNumberedListItem,
+			},
+			NumberedListItem: notionapi.ListItem{
+				RichText: richText,
+				Children: children,
+			},
+		})
+	}
+
+	if len(items) == 0 {
+		return nil, nil
+	}
+
+	heading := &notionapi.Heading3Block{
+		BasicBlock: notionapi.BasicBlock{
+			Object: notionapi.ObjectTypeBlock,
+			Type:   notionapi.BlockTypeHeading3,
+		},
+		Heading3: notionapi.Heading{
+			RichText: chunk.RichText(footnotesTitle, nil),
+		},
+	}
+
+	return append([]notionapi.Block{newDividerBlock(), heading}, items...), nil
+}
@@ -0,0 +1,64 @@
+package converter
+
+import (
+	"testing"
+
+	"github.com/jomei/notionapi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertFootnotes(t *testing.T) {
+	t.Run("renders references as superscript markers", func(t *testing.T) {
+		runs := convertInlineMarkdown("Text[^a] and more[^b].\n\n[^a]: First.\n[^b]: Second.\n")
+
+		assert.Equal(t, []inlineRun{
+			{content: "Text"},
+			{content: "¹"},
+			{content: " and more"},
+			{content: "²"},
+			{content: "."},
+		}, runs)
+	})
+
+	t.Run("collects definitions into a trailing section", func(t *testing.T) {
+		blocks := convertMarkdown(t, &Converter{}, "Text[^1].\n\n[^1]: See [the docs](https://example.com) for **details**.\n\n# Next\n")
+
+		assert.Len(t, blocks, 5)
+		assert.Equal(t, "Text¹.", blocks[0].GetRichTextString())
+		assert.Equal(t, "Next", blocks[1].GetRichTextString())
+		assert.Equal(t, notionapi.BlockTypeDivider, blocks[2].GetType())
+		assert.Equal(t, notionapi.BlockTypeHeading3, blocks[3].GetType())
+		assert.Equal(t, "Footnotes", blocks[3].GetRichTextString())
+
+		item, ok := blocks[4].(*notionapi.NumberedListItemBlock)
+		assert.True(t, ok)
+		assert.Equal(t, "See the docs for details.", item.GetRichTextString())
+		richText := item.NumberedListItem.RichText
+		assert.Equal(t, "https://example.com", richText[1].Text.Link.Url)
+		assert.True(t, richText[3].Annotations.Bold)
+	})
+
+	t.Run("keeps further paragraphs of a definition as children", func(t *testing.T) {
+		blocks := convertMarkdown(t, &Converter{}, "Text[^note].\n\n[^note]: First paragraph.\n\n    Second paragraph.\n")
+
+		assert.Len(t, blocks, 4)
+		item := blocks[3].(*notionapi.NumberedListItemBlock)
+		assert.Equal(t, "First paragraph.", item.GetRichTextString())
+		assert.Len(t, item.NumberedListItem.Children, 1)
+		assert.Equal(t, "Second paragraph.", item.NumberedListItem.Children[0].GetRichTextString())
+	})
+
+	t.Run("keeps footnotes out of deep heading toggles", func(t *testing.T) {
+		blocks := convertMarkdown(t, &Converter{DeepHeadingStyle: DeepHeadingToggle}, "#### Section\n\nText[^1].\n\n[^1]: Note.\n")
+
+		assert.Len(t, blocks, 4)
+		assert.Equal(t, notionapi.BlockTypeToggle, blocks[0].GetType())
+		assert.Equal(t, notionapi.BlockTypeDivider, blocks[1].GetType())
+	})
+
+	t.Run("omits the section without references", func(t *testing.T) {
+		blocks := convertMarkdown(t, &Converter{}, "Text.\n")
+
+		assert.Len(t, blocks, 1)
+	})
+}
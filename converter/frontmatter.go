@@ -0,0 +1,115 @@
+package converter
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/jomei/notionapi"
+	"gopkg.in/yaml.v3"
+)
+
+// FrontMatter is the YAML front matter of a markdown file, delimited by "---" lines at its top.
+type FrontMatter map[string]any
+
+// splitFrontMatter parses the front matter at the top of source and returns it with the rest of source.
+// Like pandoc, the opening "---" line must not be followed by a blank line. Without a closing line, with
+// a blank line after the opening one or with an empty block, source is returned as is with nil front matter,
+// so that a document opening with a horizontal rule keeps its content. A block that is not a YAML mapping
+// is an error rather than silently uploaded as markdown.
+func splitFrontMatter(source []byte) (FrontMatter, []byte, error) {
+	firstLine, rest, ok := bytes.Cut(source, []byte("\n"))
+	if !ok || string(bytes.TrimRight(firstLine, " \t\r")) != "---" {
+		return nil, source, nil
+	}
+
+	// The front matter ends at a "---" or "..." line
+	for offset := 0; offset < len(rest); {
+		line := rest[offset:]
+		if end := bytes.IndexByte(line, '\n'); end >= 0 {
+			line = line[:end+1]
+		}
+
+		if offset == 0 && len(bytes.TrimSpace(line)) == 0 {
+			return nil, source, nil
+		}
+
+		if delimiter := string(bytes.TrimRight(line, " \t\r\n")); delimiter == "---" || delimiter == "..." {
+			var frontMatter FrontMatter
+			if err := yaml.Unmarshal(rest[:offset], &frontMatter); err != nil {
+				return nil, nil, fmt.Errorf("invalid front matter: %w", err)
+			}
+			if len(frontMatter) == 0 {
+				return nil, source, nil
+			}
+			return frontMatter, rest[offset+len(line):], nil
+		}
+		offset += len(line)
+	}
+
+	// Without a closing line, the first line is a thematic break
+	return nil, source, nil
+}
+
+// String returns the value of a key as a string, or an empty string if it is missing or not a scalar.
+func (f FrontMatter) String(key string) string {
+	switch value := f[key].(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case time.Time:
+		// yaml.v3 decodes unquoted dates like 2024-01-02 into time.Time
+		return value.Format(time.DateOnly)
+	case bool, int, int64, uint64, float64:
+		return fmt.Sprint(value)
+	}
+	return ""
+}
+
+// Title returns the page title of the front matter.
+func (f FrontMatter) Title() string {
+	return f.String("title")
+}
+
+// Icon returns the page icon of the front matter, an external image for a URL and an emoji otherwise,
+// or nil if it has no icon.
+func (f FrontMatter) Icon() *notionapi.Icon {
+	icon := f.String("icon")
+	if icon == "" {
+		return nil
+	}
+
+	if isExternalURL(icon) {
+		return &notionapi.Icon{
+			Type:     "external",
+			External: &notionapi.FileObject{URL: icon},
+		}
+	}
+
+	emoji := notionapi.Emoji(icon)
+	return &notionapi.Icon{
+		Type:  "emoji",
+		Emoji: &emoji,
+	}
+}
+
+// Cover returns the page cover image of the front matter, or nil if it has no cover.
+func (f FrontMatter) Cover() *notionapi.Image {
+	cover := f.String("cover")
+	if cover == "" {
+		return nil
+	}
+
+	return &notionapi.Image{
+		Type:     "external",
+		External: &notionapi.FileObject{URL: cover},
+	}
+}
+
+// isExternalURL checks if a value is an http or https URL.
+func isExternalURL(value string) bool {
+	u, err := url.Parse(value)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
@@ -0,0 +1,156 @@
+package converter
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jomei/notionapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitFrontMatter(t *testing.T) {
+	t.Run("parses and strips front matter", func(t *testing.T) {
+		frontMatter, rest, err := splitFrontMatter([]byte("---\ntitle: Design\ntags: [a, b]\n---\n# Body\n"))
+
+		require.NoError(t, err)
+		assert.Equal(t, FrontMatter{"title": "Design", "tags": []any{"a", "b"}}, frontMatter)
+		assert.Equal(t, "# Body\n", string(rest))
+	})
+
+	t.Run("accepts dots as closing line", func(t *testing.T) {
+		frontMatter, rest, err := splitFrontMatter([]byte("---\r\ntitle: Design\r\n...\r\nBody"))
+
+		require.NoError(t, err)
+		assert.Equal(t, "Design", frontMatter.Title())
+		assert.Equal(t, "Body", string(rest))
+	})
+
+	t.Run("keeps source without front matter", func(t *testing.T) {
+		for _, source := range []string{
+			"# Title\n---\n",
+			"---\nno closing line\n",
+			"----\ntitle: x\n----\n",
+			"---\n---\nBody",
+			"---\n# comment\n---\nBody",
+			// Horizontal rules with a blank line after the opening one
+			"---\n\n# Title\n\nSome text: here\n\n---\n\nmore",
+		} {
+			frontMatter, rest, err := splitFrontMatter([]byte(source))
+
+			require.NoError(t, err)
+			assert.Nil(t, frontMatter)
+			assert.Equal(t, source, string(rest))
+		}
+	})
+
+	t.Run("returns error for invalid front matter", func(t *testing.T) {
+		for _, source := range []string{
+			"---\ntitle: foo: bar\n---\nBody",
+			"---\ntags: [a\n---\nBody",
+			"---\nIntro paragraph.\n---\n",
+		} {
+			_, _, err := splitFrontMatter([]byte(source))
+
+			assert.ErrorContains(t, err, "invalid front matter")
+		}
+	})
+}
+
+func TestFrontMatter(t *testing.T) {
+	t.Run("reads scalars as strings", func(t *testing.T) {
+		frontMatter := FrontMatter{"title": "Design", "count": 3, "draft": true, "tags": []any{"a"}}
+
+		assert.Equal(t, "Design", frontMatter.Title())
+		assert.Equal(t, "3", frontMatter.String("count"))
+		assert.Equal(t, "true", frontMatter.String("draft"))
+		assert.Equal(t, "", frontMatter.String("tags"))
+		assert.Equal(t, "", frontMatter.String("missing"))
+	})
+
+	t.Run("reads parsed dates as dates", func(t *testing.T) {
+		frontMatter, _, err := splitFrontMatter([]byte("---\ndate: 2024-01-02\nquoted: \"2024-01-02\"\n---\n"))
+
+		require.NoError(t, err)
+		assert.Equal(t, "2024-01-02", frontMatter.String("date"))
+		assert.Equal(t, "2024-01-02", frontMatter.String("quoted"))
+	})
+
+	t.Run("reads emoji icon", func(t *testing.T) {
+		icon := FrontMatter{"icon": "📝"}.Icon()
+
+		require.NotNil(t, icon)
+		assert.Equal(t, notionapi.FileType("emoji"), icon.Type)
+		assert.Equal(t, notionapi.Emoji("📝"), *icon.Emoji)
+	})
+
+	t.Run("reads URL icon and cover", func(t *testing.T) {
+		frontMatter := FrontMatter{"icon": "https://example.com/icon.png", "cover": "https://example.com/cover.png"}
+
+		icon := frontMatter.Icon()
+		require.NotNil(t, icon)
+		assert.Equal(t, notionapi.FileType("external"), icon.Type)
+		assert.Equal(t, "https://example.com/icon.png", icon.External.URL)
+
+		cover := frontMatter.Cover()
+		require.NotNil(t, cover)
+		assert.Equal(t, "https://example.com/cover.png", cover.External.URL)
+	})
+
+	t.Run("returns nil without icon and cover", func(t *testing.T) {
+		assert.Nil(t, FrontMatter{}.Icon())
+		assert.Nil(t, FrontMatter{}.Cover())
+	})
+}
+
+func TestConvertDocument(t *testing.T) {
+	t.Run("returns front matter with blocks of the body", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "test.md")
+		require.NoError(t, os.WriteFile(path, []byte("---\ntitle: Design\nicon: 📝\n---\n\nBody\n"), 0o644))
+
+		document, err := ConvertDocument(&Converter{MarkdownFilePath: path})
+
+		require.NoError(t, err)
+		assert.Equal(t, FrontMatter{"title": "Design", "icon": "📝"}, document.FrontMatter)
+		assert.Len(t, document.Blocks, 1)
+		assert.Equal(t, "Body", document.Blocks[0].GetRichTextString())
+	})
+
+	t.Run("converts horizontal rules at the top as dividers", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "test.md")
+		require.NoError(t, os.WriteFile(path, []byte("---\n\n# Title\n\nSome text: here\n\n---\n\nmore\n"), 0o644))
+
+		document, err := ConvertDocument(&Converter{MarkdownFilePath: path})
+
+		require.NoError(t, err)
+		assert.Nil(t, document.FrontMatter)
+		var types []notionapi.BlockType
+		for _, block := range document.Blocks {
+			types = append(types, block.GetType())
+		}
+		assert.Equal(t, []notionapi.BlockType{
+			notionapi.BlockTypeDivider, notionapi.BlockTypeHeading1, notionapi.BlockTypeParagraph,
+			notionapi.BlockTypeDivider, notionapi.BlockTypeParagraph,
+		}, types)
+	})
+
+	t.Run("returns error for invalid front matter", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "test.md")
+		require.NoError(t, os.WriteFile(path, []byte("---\ntitle: foo: bar\ntags: [a\n---\n\nBody\n"), 0o644))
+
+		_, err := ConvertDocument(&Converter{MarkdownFilePath: path})
+
+		assert.ErrorContains(t, err, "invalid front matter")
+	})
+
+	t.Run("returns the first level 1 heading", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "test.md")
+		require.NoError(t, os.WriteFile(path, []byte("## Intro\n\n# The **Design** Doc\n\n# Second\n"), 0o644))
+
+		document, err := ConvertDocument(&Converter{MarkdownFilePath: path})
+
+		require.NoError(t, err)
+		assert.Equal(t, "The Design Doc", document.Heading)
+	})
+}
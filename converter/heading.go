@@ -2,10 +2,22 @@ package converter
 
 import (
   "fmt"
+  "strings"
 
   "github.com/jomei/notionapi"
-  "github.com/sioncojp/go-markdown-to-notion/chunk"
   "github.com/yuin/goldmark/ast"
+  east "github.com/yuin/goldmark/extension/ast"
+)
+
+// Deep heading styles decide how headings of level 4 to 6 are converted,
+// since Notion only has three heading levels.
+const (
+  // DeepHeadingHeading3 clamps deep headings to heading_3.
+  DeepHeadingHeading3 = "heading_3"
+  // DeepHeadingParagraph converts deep headings to bold paragraphs.
+  DeepHeadingParagraph = "paragraph"
+  // DeepHeadingToggle converts deep headings to toggles holding the content of their section.
+  DeepHeadingToggle = "toggle"
 )
 
 func isHeading(node ast.Node) bool {
@@ -13,6 +25,52 @@ func isHeading(node ast.Node) bool {
   return ok
 }
 
+// isDeepHeading checks if a node is a heading of level 4 or deeper.
+func isDeepHeading(node ast.Node) bool {
+  heading, ok := node.(*ast.Heading)
+  return ok && heading.Level > 3
+}
+
+// validateDeepHeadingStyle checks if the deep heading style is a known option.
+func validateDeepHeadingStyle(style string) bool {
+  switch style {
+  case "", DeepHeadingHeading3, DeepHeadingParagraph, DeepHeadingToggle:
+    return true
+  }
+  return false
+}
+
+// convertHeadingRichText converts the inline content of a heading to Notion rich text.
+func convertHeadingRichText(node *ast.Heading, source []byte, style inlineStyle) []notionapi.RichText {
+  richText := convertStyledChildren(node, source, style)
+  if len(richText) > 0 {
+    return richText
+  }
+
+  // Extract heading text from the node lines if there are no inline children
+  headingText := string(node.Lines().Value(source))
+  if headingText == "" {
+    return nil
+  }
+  return newStyledRichText(headingText, style)
+}
+
+// firstHeading returns the plain text of the first level 1 heading in a document.
+func firstHeading(document ast.Node, source []byte) string {
+  for child := document.FirstChild(); child != nil; child = child.NextSibling() {
+    if heading, ok := child.(*ast.Heading); ok && heading.Level == 1 {
+      var text strings.Builder
+      for _, richText := range convertHeadingRichText(heading, source, inlineStyle{}) {
+        text.WriteString(richText.PlainText)
+      }
+      return strings.TrimSpace(text.String())
+    }
+  }
+  return ""
+}
+
+// convertHeading converts a heading node to a Notion heading block.
+// Headings deeper than level 3 are clamped to heading_3.
 func convertHeading(node *ast.Heading, source []byte, h1Color, h2Color, h3Color string) notionapi.Block {
   // Handle nil node
   if node == nil {
@@ -21,11 +79,11 @@ func convertHeading(node *ast.Heading, source []byte, h1Color, h2Color, h3Color
 
   var block notionapi.Block
 
-  // Extract heading text from the node
-  headingText := string(node.Lines().Value(source))
+  // Convert heading text, keeping inline formatting
+  richText := convertHeadingRichText(node, source, inlineStyle{})
 
   // Handle empty heading text
-  if headingText == "" {
+  if len(richText) == 0 {
     return nil
   }
 
@@ -36,7 +94,7 @@ func convertHeading(node *ast.Heading, source []byte, h1Color, h2Color, h3Color
         Type:   notionapi.BlockTypeHeading1,
       },
       Heading1: notionapi.Heading{
-        RichText:     chunk.RichText(headingText, nil),
+        RichText:     richText,
         Children:     nil,
         Color:        fmt.Sprintf("%s_background", h1Color),
         IsToggleable: false,
@@ -51,7 +109,7 @@ func convertHeading(node *ast.Heading, source []byte, h1Color, h2Color, h3Color
         Type:   notionapi.BlockTypeHeading2,
       },
       Heading2: notionapi.Heading{
-        RichText:     chunk.RichText(headingText, nil),
+        RichText:     richText,
         Children:     nil,
         Color:        fmt.Sprintf("%s_background", h2Color),
         IsToggleable: false,
@@ -59,14 +117,14 @@ func convertHeading(node *ast.Heading, source []byte, h1Color, h2Color, h3Color
     }
   }
 
-  if node.Level == 3 {
+  if node.Level >= 3 {
     block = notionapi.Heading3Block{
       BasicBlock: notionapi.BasicBlock{
         Object: notionapi.ObjectTypeBlock,
         Type:   notionapi.BlockTypeHeading3,
       },
       Heading3: notionapi.Heading{
-        RichText:     chunk.RichText(headingText, nil),
+        RichText:     richText,
         Children:     nil,
         Color:        fmt.Sprintf("%s_background", h3Color),
         IsToggleable: false,
@@ -76,3 +134,53 @@ func convertHeading(node *ast.Heading, source []byte, h1Color, h2Color, h3Color
 
   return block
 }
+
+// convertDeepHeadingParagraph converts a heading of level 4 or deeper to a bold Notion paragraph block.
+func convertDeepHeadingParagraph(node *ast.Heading, source []byte) *notionapi.ParagraphBlock {
+  if node == nil {
+    return nil
+  }
+
+  richText := convertHeadingRichText(node, source, inlineStyle{annotations: notionapi.Annotations{Bold: true}})
+  if len(richText) == 0 {
+    return nil
+  }
+
+  return newParagraphBlock(richText)
+}
+
+// convertDeepHeadingToggle converts a heading of level 4 or deeper to a Notion toggle block
+// holding the blocks of its section as children.
+func convertDeepHeadingToggle(node *ast.Heading, source []byte, children []notionapi.Block) *notionapi.ToggleBlock {
+  if node == nil {
+    return nil
+  }
+
+  richText := convertHeadingRichText(node, source, inlineStyle{annotations: notionapi.Annotations{Bold: true}})
+  if len(richText) == 0 {
+    return nil
+  }
+
+  return &notionapi.ToggleBlock{
+    BasicBlock: notionapi.BasicBlock{
+      Object: notionapi.ObjectTypeBlock,
+      Type:   notionapi.BlockTypeToggle,
+    },
+    Toggle: notionapi.Toggle{
+      RichText: richText,
+      Children: children,
+      Color:    "default",
+    },
+  }
+}
+
+// endsSection checks if a node ends the section started by a heading of the given level.
+func endsSection(node ast.Node, level int) bool {
+  // The footnotes trail the document rather than its last section
+  if _, ok := node.(*east.FootnoteList); ok {
+    return true
+  }
+
+  heading, ok := node.(*ast.Heading)
+  return ok && heading.Level <= level
+}
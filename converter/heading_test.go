@@ -150,3 +150,96 @@ func assertHeadingBlockEqual(t *testing.T, expected, actual notionapi.Block) {
     t.Fatalf("unexpected block type: %T", expected)
   }
 }
+
+func TestConvertHeadingInlineFormatting(t *testing.T) {
+  t.Run("keeps bold and links in heading text", func(t *testing.T) {
+    source := []byte("## Using **bold** and [links](https://example.com)")
+    node := parseMarkdown(source).FirstChild().(*ast.Heading)
+
+    result := convertHeading(node, source, "blue", "red", "green")
+
+    heading, ok := result.(notionapi.Heading2Block)
+    assert.True(t, ok)
+    assert.Equal(t, "Using bold and links", heading.GetRichTextString())
+    assert.True(t, findRichText(t, heading.Heading2.RichText, "bold").Annotations.Bold)
+    assert.Equal(t, "https://example.com", findRichText(t, heading.Heading2.RichText, "links").Text.Link.Url)
+  })
+
+  t.Run("clamps deep headings to heading 3", func(t *testing.T) {
+    source := []byte("##### Deep heading")
+    node := parseMarkdown(source).FirstChild().(*ast.Heading)
+
+    result := convertHeading(node, source, "blue", "red", "green")
+
+    heading, ok := result.(notionapi.Heading3Block)
+    assert.True(t, ok)
+    assert.Equal(t, "Deep heading", heading.GetRichTextString())
+    assert.Equal(t, "green_background", heading.Heading3.Color)
+  })
+}
+
+func TestConvertDeepHeading(t *testing.T) {
+  markdown := "## Section\n\n#### Details\n\nFirst\n\n- item\n\n#### More\n\nSecond\n\n## Next\n"
+
+  t.Run("clamps to heading 3 by default", func(t *testing.T) {
+    blocks := convertMarkdown(t, &Converter{}, markdown)
+
+    assert.Len(t, blocks, 7)
+    assert.IsType(t, notionapi.Heading3Block{}, blocks[1])
+    assert.IsType(t, notionapi.Heading3Block{}, blocks[4])
+  })
+
+  t.Run("converts to bold paragraphs", func(t *testing.T) {
+    blocks := convertMarkdown(t, &Converter{DeepHeadingStyle: DeepHeadingParagraph}, markdown)
+
+    assert.Len(t, blocks, 7)
+    paragraph, ok := blocks[1].(*notionapi.ParagraphBlock)
+    assert.True(t, ok)
+    assert.Equal(t, "Details", paragraph.GetRichTextString())
+    assert.True(t, paragraph.Paragraph.RichText[0].Annotations.Bold)
+  })
+
+  t.Run("converts to toggles holding their section", func(t *testing.T) {
+    blocks := convertMarkdown(t, &Converter{DeepHeadingStyle: DeepHeadingToggle}, markdown)
+
+    assert.Len(t, blocks, 4)
+    details, ok := blocks[1].(*notionapi.ToggleBlock)
+    assert.True(t, ok)
+    assert.Equal(t, "Details", details.GetRichTextString())
+    assert.Len(t, details.Toggle.Children, 2)
+    assert.Equal(t, notionapi.BlockTypeParagraph, details.Toggle.Children[0].GetType())
+    assert.Equal(t, notionapi.BlockTypeBulletedListItem, details.Toggle.Children[1].GetType())
+
+    more, ok := blocks[2].(*notionapi.ToggleBlock)
+    assert.True(t, ok)
+    assert.Len(t, more.Toggle.Children, 1)
+    assert.IsType(t, notionapi.Heading2Block{}, blocks[3])
+  })
+
+  t.Run("nests toggles of deeper headings", func(t *testing.T) {
+    blocks := convertMarkdown(t, &Converter{DeepHeadingStyle: DeepHeadingToggle}, "#### Outer\n\nintro\n\n##### Inner\n\ninner text\n\n#### Next\n")
+
+    assert.Len(t, blocks, 2)
+    outer, ok := blocks[0].(*notionapi.ToggleBlock)
+    assert.True(t, ok)
+    assert.Equal(t, "Outer", outer.GetRichTextString())
+    assert.Len(t, outer.Toggle.Children, 2)
+    assert.Equal(t, notionapi.BlockTypeParagraph, outer.Toggle.Children[0].GetType())
+
+    inner, ok := outer.Toggle.Children[1].(*notionapi.ToggleBlock)
+    assert.True(t, ok)
+    assert.Equal(t, "Inner", inner.GetRichTextString())
+    assert.Len(t, inner.Toggle.Children, 1)
+    assert.Equal(t, "inner text", inner.Toggle.Children[0].GetRichTextString())
+
+    next, ok := blocks[1].(*notionapi.ToggleBlock)
+    assert.True(t, ok)
+    assert.Equal(t, "Next", next.GetRichTextString())
+  })
+
+  t.Run("returns error for unknown style", func(t *testing.T) {
+    c := &Converter{DeepHeadingStyle: "unknown", MarkdownFilePath: "../sample.md"}
+    _, err := Convert(c)
+    assert.Error(t, err)
+  })
+}
@@ -0,0 +1,312 @@
+package converter
+
+import (
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/jomei/notionapi"
+	"github.com/sioncojp/go-markdown-to-notion/chunk"
+	"github.com/yuin/goldmark/ast"
+)
+
+// HTML block policies decide how raw HTML blocks are converted.
+const (
+	// HTMLBlockDrop drops raw HTML blocks.
+	HTMLBlockDrop = "drop"
+	// HTMLBlockCode shows raw HTML blocks as html code blocks.
+	HTMLBlockCode = "code"
+	// HTMLBlockParse converts known tags (img, hr, br, details/summary) and keeps the text of the rest.
+	HTMLBlockParse = "parse"
+)
+
+var (
+	// htmlCommentRegexp matches HTML comments.
+	htmlCommentRegexp = regexp.MustCompile(`(?s)<!--.*?-->`)
+
+	// htmlDetailsRegexp matches a details element and captures its content.
+	htmlDetailsRegexp = regexp.MustCompile(`(?is)<details\b[^>]*>(.*?)</details\s*>`)
+
+	// htmlDetailsOpenRegexp matches an opening details tag.
+	htmlDetailsOpenRegexp = regexp.MustCompile(`(?i)<details\b[^>]*>`)
+
+	// htmlDetailsCloseRegexp matches a closing details tag.
+	htmlDetailsCloseRegexp = regexp.MustCompile(`(?i)</details\s*>`)
+
+	// htmlSummaryRegexp matches a summary element and captures its content.
+	htmlSummaryRegexp = regexp.MustCompile(`(?is)<summary\b[^>]*>(.*?)</summary\s*>`)
+
+	// htmlKnownTagRegexp matches the void tags converted to their own blocks or line breaks.
+	htmlKnownTagRegexp = regexp.MustCompile(`(?i)<(img|hr|br)\b[^>]*>`)
+
+	// htmlTagRegexp matches any tag.
+	htmlTagRegexp = regexp.MustCompile(`(?s)</?[A-Za-z][^>]*>`)
+
+	// htmlAttributeRegexp matches a tag attribute and captures its name and value.
+	htmlAttributeRegexp = regexp.MustCompile(`([A-Za-z_:][-A-Za-z0-9_:.]*)\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s"'>]+))`)
+
+	// whitespaceRegexp matches runs of whitespace.
+	whitespaceRegexp = regexp.MustCompile(`\s+`)
+)
+
+// isHTMLBlock checks if a node is a raw HTML block.
+func isHTMLBlock(node ast.Node) bool {
+	_, ok := node.(*ast.HTMLBlock)
+	return ok
+}
+
+// validateHTMLBlockPolicy checks if the HTML block policy is a known option.
+func validateHTMLBlockPolicy(policy string) bool {
+	switch policy {
+	case "", HTMLBlockDrop, HTMLBlockCode, HTMLBlockParse:
+		return true
+	}
+	return false
+}
+
+// htmlBlockContent returns the raw HTML of an HTML block node, including its closing line.
+func htmlBlockContent(node *ast.HTMLBlock, source []byte) string {
+	content := string(node.Lines().Value(source))
+	if node.HasClosure() {
+		content += string(node.ClosureLine.Value(source))
+	}
+	return content
+}
+
+// convertHTMLBlockToCode converts an HTML block node to a Notion code block in html.
+func convertHTMLBlockToCode(node *ast.HTMLBlock, source []byte) *notionapi.CodeBlock {
+	if node == nil {
+		return nil
+	}
+
+	content := htmlBlockContent(node, source)
+	if strings.TrimSpace(content) == "" {
+		return nil
+	}
+
+	return &notionapi.CodeBlock{
+		BasicBlock: notionapi.BasicBlock{
+			Object: notionapi.ObjectTypeBlock,
+			Type:   notionapi.BlockTypeCode,
+		},
+		Code: notionapi.Code{
+			RichText: chunk.RichText(content, nil),
+			Language: "html",
+		},
+	}
+}
+
+// convertHTMLBlock converts an HTML block node according to the HTML block policy.
+func (c *Converter) convertHTMLBlock(node *ast.HTMLBlock, source []byte) ([]notionapi.Block, error) {
+	if node == nil {
+		return nil, nil
+	}
+
+	switch c.HTMLBlockPolicy {
+	case HTMLBlockCode:
+		if codeBlock := convertHTMLBlockToCode(node, source); codeBlock != nil {
+			return []notionapi.Block{codeBlock}, nil
+		}
+		return nil, nil
+	case HTMLBlockParse:
+		return c.parseHTML(htmlBlockContent(node, source))
+	}
+
+	return nil, nil
+}
+
+// parseHTML converts an HTML fragment to Notion blocks.
+// details elements become toggles, img and hr tags become their own blocks,
+// and the text of any other element is kept as paragraphs.
+func (c *Converter) parseHTML(content string) ([]notionapi.Block, error) {
+	content = htmlCommentRegexp.ReplaceAllString(content, "")
+
+	var blocks []notionapi.Block
+	last := 0
+	for _, match := range htmlDetailsRegexp.FindAllStringSubmatchIndex(content, -1) {
+		before, err := c.parseHTMLInline(content[last:match[0]])
+		if err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, before...)
+
+		details, err := c.parseHTMLDetails(content[match[2]:match[3]])
+		if err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, details...)
+		last = match[1]
+	}
+
+	rest, err := c.parseHTMLInline(content[last:])
+	if err != nil {
+		return nil, err
+	}
+	return append(blocks, rest...), nil
+}
+
+// parseHTMLDetails converts the content of a details element to a Notion toggle block.
+func (c *Converter) parseHTMLDetails(content string) ([]notionapi.Block, error) {
+	summary, content := detailsSummary(content)
+	children, err := c.parseHTML(content)
+	if err != nil {
+		return nil, err
+	}
+
+	return []notionapi.Block{newDetailsToggle(summary, children)}, nil
+}
+
+// detailsSummary returns the summary text of the content of a details element, "Details" if it has none,
+// and the content without the summary element.
+func detailsSummary(content string) (string, string) {
+	summary := "Details"
+	if match := htmlSummaryRegexp.FindStringSubmatchIndex(content); match != nil {
+		if text := htmlText(content[match[2]:match[3]]); text != "" {
+			summary = text
+		}
+		content = content[:match[0]] + content[match[1]:]
+	}
+	return summary, content
+}
+
+// newDetailsToggle creates the Notion toggle block of a details element.
+func newDetailsToggle(summary string, children []notionapi.Block) *notionapi.ToggleBlock {
+	return &notionapi.ToggleBlock{
+		BasicBlock: notionapi.BasicBlock{
+			Object: notionapi.ObjectTypeBlock,
+			Type:   notionapi.BlockTypeToggle,
+		},
+		Toggle: notionapi.Toggle{
+			RichText: chunk.RichText(summary, nil),
+			Children: children,
+			Color:    "default",
+		},
+	}
+}
+
+// detailsDepth returns how many details elements an HTML block opens without closing them.
+// It is negative for a block closing more than it opens, and 0 for any other node.
+func detailsDepth(node ast.Node, source []byte) int {
+	block, ok := node.(*ast.HTMLBlock)
+	if !ok {
+		return 0
+	}
+
+	content := htmlBlockContent(block, source)
+	return len(htmlDetailsOpenRegexp.FindAllStringIndex(content, -1)) - len(htmlDetailsCloseRegexp.FindAllStringIndex(content, -1))
+}
+
+// detailsEnd returns the sibling HTML block closing the details element opened by node,
+// or nil if it is not closed before end.
+func detailsEnd(node ast.Node, end ast.Node, source []byte) *ast.HTMLBlock {
+	depth := detailsDepth(node, source)
+	for sibling := node.NextSibling(); sibling != nil && sibling != end; sibling = sibling.NextSibling() {
+		depth += detailsDepth(sibling, source)
+		if depth <= 0 {
+			return sibling.(*ast.HTMLBlock)
+		}
+	}
+	return nil
+}
+
+// convertDetailsSection converts a details element spread over sibling blocks to a Notion toggle block.
+// goldmark ends an HTML block at a blank line, so the markdown body between the block opening
+// the element and the block closing it becomes the children of the toggle.
+func (c *Converter) convertDetailsSection(opener, closer *ast.HTMLBlock, source []byte) ([]notionapi.Block, error) {
+	head := htmlBlockContent(opener, source)
+	open := htmlDetailsOpenRegexp.FindStringIndex(head)
+	tail := htmlBlockContent(closer, source)
+	closes := htmlDetailsCloseRegexp.FindAllStringIndex(tail, -1)
+	closing := closes[len(closes)-1]
+
+	blocks, err := c.parseHTML(head[:open[0]])
+	if err != nil {
+		return nil, err
+	}
+
+	summary, inner := detailsSummary(head[open[1]:])
+	children, err := c.parseHTML(inner)
+	if err != nil {
+		return nil, err
+	}
+	body, err := c.convertSiblingsUntil(opener.NextSibling(), closer, source)
+	if err != nil {
+		return nil, err
+	}
+	children = append(children, body...)
+	last, err := c.parseHTML(tail[:closing[0]])
+	if err != nil {
+		return nil, err
+	}
+	children = append(children, last...)
+
+	after, err := c.parseHTML(tail[closing[1]:])
+	if err != nil {
+		return nil, err
+	}
+
+	blocks = append(blocks, newDetailsToggle(summary, children))
+	return append(blocks, after...), nil
+}
+
+// parseHTMLInline converts img and hr tags to their own blocks and keeps the text around them as paragraphs.
+func (c *Converter) parseHTMLInline(content string) ([]notionapi.Block, error) {
+	var blocks []notionapi.Block
+	var lines []string
+	var text strings.Builder
+
+	// flush appends the text collected so far as a paragraph, with a newline for each br tag
+	flush := func() {
+		lines = append(lines, htmlText(text.String()))
+		if paragraph := strings.Trim(strings.Join(lines, "\n"), "\n"); paragraph != "" {
+			blocks = append(blocks, newParagraphBlock(chunk.RichText(paragraph, nil)))
+		}
+		lines = nil
+		text.Reset()
+	}
+
+	last := 0
+	for _, match := range htmlKnownTagRegexp.FindAllStringSubmatchIndex(content, -1) {
+		text.WriteString(content[last:match[0]])
+		last = match[1]
+
+		switch strings.ToLower(content[match[2]:match[3]]) {
+		case "br":
+			lines = append(lines, htmlText(text.String()))
+			text.Reset()
+		case "hr":
+			flush()
+			blocks = append(blocks, newDividerBlock())
+		case "img":
+			flush()
+			attributes := htmlAttributes(content[match[0]:match[1]])
+			imageBlock, err := c.convertImageDestination(attributes["src"], newImageCaption(attributes["alt"], attributes["title"]))
+			if err != nil {
+				return nil, err
+			}
+			if imageBlock != nil {
+				blocks = append(blocks, imageBlock)
+			}
+		}
+	}
+	text.WriteString(content[last:])
+	flush()
+
+	return blocks, nil
+}
+
+// htmlAttributes parses the attributes of a tag.
+func htmlAttributes(tag string) map[string]string {
+	attributes := map[string]string{}
+	for _, match := range htmlAttributeRegexp.FindAllStringSubmatch(tag, -1) {
+		attributes[strings.ToLower(match[1])] = html.UnescapeString(match[2] + match[3] + match[4])
+	}
+	return attributes
+}
+
+// htmlText strips the tags of an HTML fragment and collapses its whitespace.
+func htmlText(fragment string) string {
+	text := htmlTagRegexp.ReplaceAllString(fragment, " ")
+	text = whitespaceRegexp.ReplaceAllString(text, " ")
+	return strings.TrimSpace(html.UnescapeString(text))
+}
@@ -0,0 +1,104 @@
+package converter
+
+import (
+	"testing"
+
+	"github.com/jomei/notionapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/yuin/goldmark/ast"
+)
+
+func TestIsHTMLBlock(t *testing.T) {
+	t.Run("is an html block node", func(t *testing.T) {
+		node := &ast.HTMLBlock{}
+		assert.True(t, isHTMLBlock(node))
+	})
+
+	t.Run("not an html block node", func(t *testing.T) {
+		node := &ast.Paragraph{}
+		assert.False(t, isHTMLBlock(node))
+	})
+}
+
+func TestConvertHTMLBlock(t *testing.T) {
+	markdown := "<p>\n  <img src=\"https://example.com/1.png\" alt=\"one\">\n  <img src='https://example.com/2.png'>\n</p>\n"
+
+	t.Run("drops html blocks by default", func(t *testing.T) {
+		blocks := convertMarkdown(t, &Converter{}, markdown)
+		assert.Empty(t, blocks)
+	})
+
+	t.Run("shows html blocks as html code", func(t *testing.T) {
+		blocks := convertMarkdown(t, &Converter{HTMLBlockPolicy: HTMLBlockCode}, markdown)
+
+		assert.Len(t, blocks, 1)
+		codeBlock := blocks[0].(*notionapi.CodeBlock)
+		assert.Equal(t, "html", codeBlock.Code.Language)
+		assert.Equal(t, markdown, codeBlock.Code.RichText[0].PlainText)
+	})
+
+	t.Run("parses images", func(t *testing.T) {
+		blocks := convertMarkdown(t, &Converter{HTMLBlockPolicy: HTMLBlockParse}, markdown)
+
+		assert.Len(t, blocks, 2)
+		first := blocks[0].(*notionapi.ImageBlock)
+		assert.Equal(t, "https://example.com/1.png", first.Image.External.URL)
+		assert.Equal(t, "one", first.Image.Caption[0].PlainText)
+		second := blocks[1].(*notionapi.ImageBlock)
+		assert.Equal(t, "https://example.com/2.png", second.Image.External.URL)
+		assert.Empty(t, second.Image.Caption)
+	})
+
+	t.Run("parses details, text, line breaks and rules", func(t *testing.T) {
+		markdown := "<div>\n<!-- hidden -->\nFirst &amp; <b>bold</b><br>next line\n<hr/>\n<details>\n<summary>More</summary>\nInside\n</details>\n</div>\n"
+		blocks := convertMarkdown(t, &Converter{HTMLBlockPolicy: HTMLBlockParse}, markdown)
+
+		assert.Len(t, blocks, 3)
+		assert.Equal(t, "First & bold\nnext line", blocks[0].GetRichTextString())
+		assert.Equal(t, notionapi.BlockTypeDivider, blocks[1].GetType())
+		toggle := blocks[2].(*notionapi.ToggleBlock)
+		assert.Equal(t, "More", toggle.GetRichTextString())
+		assert.Len(t, toggle.Toggle.Children, 1)
+		assert.Equal(t, "Inside", toggle.Toggle.Children[0].GetRichTextString())
+	})
+
+	t.Run("converts details around markdown to a toggle", func(t *testing.T) {
+		markdown := "<details>\n<summary>Click</summary>\n\nmarkdown body\n\n</details>\n\nAfter\n"
+		blocks := convertMarkdown(t, &Converter{HTMLBlockPolicy: HTMLBlockParse}, markdown)
+
+		assert.Len(t, blocks, 2)
+		toggle := blocks[0].(*notionapi.ToggleBlock)
+		assert.Equal(t, "Click", toggle.GetRichTextString())
+		assert.Len(t, toggle.Toggle.Children, 1)
+		assert.Equal(t, notionapi.BlockTypeParagraph, toggle.Toggle.Children[0].GetType())
+		assert.Equal(t, "markdown body", toggle.Toggle.Children[0].GetRichTextString())
+		assert.Equal(t, "After", blocks[1].GetRichTextString())
+	})
+
+	t.Run("nests details around markdown", func(t *testing.T) {
+		markdown := "<details><summary>Outer</summary>\n\n- item\n\n<details>\n\nInner body\n\n</details>\n\n</details>\n"
+		blocks := convertMarkdown(t, &Converter{HTMLBlockPolicy: HTMLBlockParse}, markdown)
+
+		assert.Len(t, blocks, 1)
+		outer := blocks[0].(*notionapi.ToggleBlock)
+		assert.Equal(t, "Outer", outer.GetRichTextString())
+		assert.Len(t, outer.Toggle.Children, 2)
+		assert.Equal(t, notionapi.BlockTypeBulletedListItem, outer.Toggle.Children[0].GetType())
+		inner := outer.Toggle.Children[1].(*notionapi.ToggleBlock)
+		assert.Equal(t, "Details", inner.GetRichTextString())
+		assert.Equal(t, "Inner body", inner.Toggle.Children[0].GetRichTextString())
+	})
+
+	t.Run("keeps an unclosed details as text", func(t *testing.T) {
+		blocks := convertMarkdown(t, &Converter{HTMLBlockPolicy: HTMLBlockParse}, "<details>\n<summary>Click</summary>\n\nbody\n")
+
+		assert.Len(t, blocks, 2)
+		assert.Equal(t, "Click", blocks[0].GetRichTextString())
+		assert.Equal(t, "body", blocks[1].GetRichTextString())
+	})
+
+	t.Run("returns error for unknown policy", func(t *testing.T) {
+		_, err := Convert(&Converter{HTMLBlockPolicy: "unknown", MarkdownFilePath: "../sample.md"})
+		assert.Error(t, err)
+	})
+}
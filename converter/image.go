@@ -1 +1,346 @@
 package converter
+
+import (
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jomei/notionapi"
+	"github.com/sioncojp/go-markdown-to-notion/chunk"
+	"github.com/yuin/goldmark/ast"
+)
+
+// ImageUploader uploads image bytes to Notion and returns the ID of the file upload.
+type ImageUploader func(filename, contentType string, data []byte) (string, error)
+
+// UploadedImageBlock is an image block referencing a file uploaded through the Notion API.
+// notionapi.Image has no field for the file_upload type, so it is defined here.
+type UploadedImageBlock struct {
+	notionapi.BasicBlock
+	Image UploadedImage `json:"image"`
+}
+
+// UploadedImage ... image object of UploadedImageBlock.
+type UploadedImage struct {
+	Caption    []notionapi.RichText `json:"caption,omitempty"`
+	Type       notionapi.FileType   `json:"type"`
+	FileUpload FileUpload           `json:"file_upload"`
+}
+
+// FileUpload ... reference to a Notion file upload.
+type FileUpload struct {
+	ID string `json:"id"`
+}
+
+// fileTypeFileUpload is the image type for files uploaded through the Notion API.
+const fileTypeFileUpload notionapi.FileType = "file_upload"
+
+// isImage checks if a node is an image.
+func isImage(node ast.Node) bool {
+	_, ok := node.(*ast.Image)
+	return ok
+}
+
+// hasImage checks if any direct child of a node is an image, or a link around an image like a badge.
+func hasImage(node ast.Node) bool {
+	for child := node.FirstChild(); child != nil; child = child.NextSibling() {
+		if isImage(child) {
+			return true
+		}
+		if _, ok := child.(*ast.Link); ok && hasImage(child) {
+			return true
+		}
+	}
+	return false
+}
+
+// imageAltText extracts the alt text of an image node.
+func imageAltText(node *ast.Image, source []byte) string {
+	var content strings.Builder
+	_ = ast.Walk(node, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch n := n.(type) {
+		case *ast.Text:
+			content.Write(n.Segment.Value(source))
+		case *ast.String:
+			content.Write(n.Value)
+		}
+		return ast.WalkContinue, nil
+	})
+	return content.String()
+}
+
+// imageCaption builds the caption of an image from its alt text and title.
+func imageCaption(node *ast.Image, source []byte) []notionapi.RichText {
+	return newImageCaption(imageAltText(node, source), string(node.Title))
+}
+
+// newImageCaption builds an image caption from alt text and title.
+func newImageCaption(alt, title string) []notionapi.RichText {
+	var parts []string
+	if alt = strings.TrimSpace(alt); alt != "" {
+		parts = append(parts, alt)
+	}
+	if title = strings.TrimSpace(title); title != "" {
+		parts = append(parts, title)
+	}
+
+	if len(parts) == 0 {
+		return nil
+	}
+	return chunk.RichText(strings.Join(parts, " - "), nil)
+}
+
+// newExternalImageBlock creates a Notion image block with an external URL.
+func newExternalImageBlock(url string, caption []notionapi.RichText) *notionapi.ImageBlock {
+	return &notionapi.ImageBlock{
+		BasicBlock: notionapi.BasicBlock{
+			Object: notionapi.ObjectTypeBlock,
+			Type:   notionapi.BlockTypeImage,
+		},
+		Image: notionapi.Image{
+			Caption: caption,
+			Type:    notionapi.FileTypeExternal,
+			External: &notionapi.FileObject{
+				URL: url,
+			},
+		},
+	}
+}
+
+// convertParagraphWithImages splits a paragraph at its images, so that each image
+// becomes its own block and the surrounding text stays in order.
+// An image inside a link keeps the link on its caption, and the rest of the link text stays linked.
+// The node may also be the text block of a tight list item.
+func (c *Converter) convertParagraphWithImages(node ast.Node, source []byte) ([]notionapi.Block, error) {
+	if node == nil {
+		return nil, nil
+	}
+
+	var blocks []notionapi.Block
+	var richTextBlocks []notionapi.RichText
+
+	// flush appends the text collected so far as a paragraph
+	flush := func() {
+		if !isBlankRichText(richTextBlocks) {
+			blocks = append(blocks, newParagraphBlock(richTextBlocks))
+		}
+		richTextBlocks = nil
+	}
+
+	// appendImage appends an image block, with its caption linked to link if set
+	appendImage := func(image *ast.Image, link string) error {
+		flush()
+		var imageBlock notionapi.Block
+		var err error
+		if link == "" {
+			imageBlock, err = c.convertImage(image, source)
+		} else {
+			imageBlock, err = c.convertImageDestination(string(image.Destination), linkCaption(imageCaption(image, source), link))
+		}
+		if err != nil {
+			return err
+		}
+		if imageBlock != nil {
+			blocks = append(blocks, imageBlock)
+		}
+		return nil
+	}
+
+	for child := node.FirstChild(); child != nil; child = child.NextSibling() {
+		switch n := child.(type) {
+		case *ast.Image:
+			if err := appendImage(n, ""); err != nil {
+				return nil, err
+			}
+		case *ast.Link:
+			if !hasImage(n) {
+				richTextBlocks = append(richTextBlocks, convertInlineNode(n, source)...)
+				continue
+			}
+			for linked := n.FirstChild(); linked != nil; linked = linked.NextSibling() {
+				if image, ok := linked.(*ast.Image); ok {
+					if err := appendImage(image, string(n.Destination)); err != nil {
+						return nil, err
+					}
+					continue
+				}
+				richTextBlocks = append(richTextBlocks, convertStyledInline(linked, source, inlineStyle{link: string(n.Destination)})...)
+			}
+		default:
+			richTextBlocks = append(richTextBlocks, convertInlineNode(child, source)...)
+		}
+	}
+	flush()
+
+	return blocks, nil
+}
+
+// convertTextWithImages converts the leading paragraph of a list item or quote that holds images.
+// It returns the text before the first image as the rich text of the item, and the images
+// and the text after them as blocks that come before the other children.
+func (c *Converter) convertTextWithImages(node ast.Node, source []byte) ([]notionapi.RichText, []notionapi.Block, error) {
+	blocks, err := c.convertParagraphWithImages(node, source)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(blocks) > 0 {
+		if paragraph, ok := blocks[0].(*notionapi.ParagraphBlock); ok {
+			return paragraph.Paragraph.RichText, blocks[1:], nil
+		}
+	}
+	return []notionapi.RichText{}, blocks, nil
+}
+
+// linkCaption links an image caption to the destination of the link around the image,
+// using the destination itself when the image has no caption.
+func linkCaption(caption []notionapi.RichText, link string) []notionapi.RichText {
+	if len(caption) == 0 {
+		return chunk.RichTextWithLink(link, link)
+	}
+
+	for i := range caption {
+		caption[i].Text = &notionapi.Text{Content: caption[i].Text.Content, Link: &notionapi.Link{Url: link}}
+	}
+	return caption
+}
+
+// convertImage converts an image node to a Notion image block.
+// Local files and data URIs are uploaded when an ImageUploader is set.
+func (c *Converter) convertImage(node *ast.Image, source []byte) (notionapi.Block, error) {
+	if node == nil {
+		return nil, nil
+	}
+
+	return c.convertImageDestination(string(node.Destination), imageCaption(node, source))
+}
+
+// convertImageDestination creates a Notion image block for an image destination,
+// uploading local files and data URIs when an ImageUploader is set.
+func (c *Converter) convertImageDestination(destination string, caption []notionapi.RichText) (notionapi.Block, error) {
+	if destination == "" {
+		return nil, nil
+	}
+
+	if c.ImageUploader == nil || !isUploadableImage(destination) {
+		return newExternalImageBlock(destination, caption), nil
+	}
+
+	filename, contentType, data, err := c.readImage(destination)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := c.ImageUploader(filename, contentType, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload image %s: %w", filename, err)
+	}
+
+	return &UploadedImageBlock{
+		BasicBlock: notionapi.BasicBlock{
+			Object: notionapi.ObjectTypeBlock,
+			Type:   notionapi.BlockTypeImage,
+		},
+		Image: UploadedImage{
+			Caption:    caption,
+			Type:       fileTypeFileUpload,
+			FileUpload: FileUpload{ID: id},
+		},
+	}, nil
+}
+
+// isUploadableImage checks if an image destination is a local file path or a data URI.
+func isUploadableImage(destination string) bool {
+	if destination == "" {
+		return false
+	}
+
+	u, err := url.Parse(destination)
+	if err != nil {
+		// Windows paths and other unparsable destinations are treated as local files
+		return true
+	}
+	return (u.Scheme == "" && u.Host == "") || u.Scheme == "file" || u.Scheme == "data"
+}
+
+// readImage reads the bytes of a local image or data URI.
+// Relative paths are resolved against the directory of the markdown file.
+func (c *Converter) readImage(destination string) (string, string, []byte, error) {
+	if strings.HasPrefix(destination, "data:") {
+		return decodeDataURI(destination)
+	}
+
+	path := strings.TrimPrefix(destination, "file://")
+	if unescaped, err := url.PathUnescape(path); err == nil {
+		path = unescaped
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(filepath.Dir(c.MarkdownFilePath), path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", "", nil, fmt.Errorf("failed to read image: %w", err)
+	}
+
+	contentType := mime.TypeByExtension(filepath.Ext(path))
+	if contentType == "" {
+		contentType = http.DetectContentType(data)
+	}
+
+	return filepath.Base(path), contentType, data, nil
+}
+
+// decodeDataURI decodes a data URI like "data:image/png;base64,..." into its bytes.
+func decodeDataURI(uri string) (string, string, []byte, error) {
+	header, payload, ok := strings.Cut(strings.TrimPrefix(uri, "data:"), ",")
+	if !ok {
+		return "", "", nil, fmt.Errorf("invalid data URI: missing comma")
+	}
+
+	params := strings.Split(header, ";")
+	contentType := params[0]
+	if contentType == "" {
+		contentType = "text/plain"
+	}
+
+	var data []byte
+	if params[len(params)-1] == "base64" {
+		decoded, err := base64.StdEncoding.DecodeString(payload)
+		if err != nil {
+			return "", "", nil, fmt.Errorf("invalid data URI: %w", err)
+		}
+		data = decoded
+	} else {
+		unescaped, err := url.PathUnescape(payload)
+		if err != nil {
+			return "", "", nil, fmt.Errorf("invalid data URI: %w", err)
+		}
+		data = []byte(unescaped)
+	}
+
+	filename := "image"
+	if extensions, err := mime.ExtensionsByType(contentType); err == nil && len(extensions) > 0 {
+		filename += extensions[0]
+	}
+
+	return filename, contentType, data, nil
+}
+
+// isBlankRichText checks if rich text contains nothing but whitespace.
+func isBlankRichText(richText []notionapi.RichText) bool {
+	for _, rt := range richText {
+		if strings.TrimSpace(rt.PlainText) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,242 @@
+package converter
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jomei/notionapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yuin/goldmark/ast"
+)
+
+// firstImage returns the first image node found in a document.
+func firstImage(node ast.Node) *ast.Image {
+	var image *ast.Image
+	_ = ast.Walk(node, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if img, ok := n.(*ast.Image); ok && entering && image == nil {
+			image = img
+		}
+		return ast.WalkContinue, nil
+	})
+	return image
+}
+
+func TestIsImage(t *testing.T) {
+	t.Run("is an image node", func(t *testing.T) {
+		node := &ast.Image{}
+		assert.True(t, isImage(node))
+	})
+
+	t.Run("not an image node", func(t *testing.T) {
+		node := &ast.Paragraph{}
+		assert.False(t, isImage(node))
+	})
+}
+
+func TestConvertImage(t *testing.T) {
+	c := &Converter{}
+
+	t.Run("handles nil image", func(t *testing.T) {
+		result, err := c.convertImage(nil, nil)
+
+		assert.NoError(t, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("converts image with alt text and title", func(t *testing.T) {
+		source := []byte(`![A cat](https://example.com/cat.png "Sleeping")`)
+		node := firstImage(parseMarkdown(source))
+
+		block, err := c.convertImage(node, source)
+
+		require.NoError(t, err)
+		result, ok := block.(*notionapi.ImageBlock)
+		require.True(t, ok)
+		assert.Equal(t, notionapi.BlockTypeImage, result.Type)
+		assert.Equal(t, notionapi.FileTypeExternal, result.Image.Type)
+		assert.Equal(t, "https://example.com/cat.png", result.Image.External.URL)
+		assert.Len(t, result.Image.Caption, 1)
+		assert.Equal(t, "A cat - Sleeping", result.Image.Caption[0].PlainText)
+	})
+
+	t.Run("converts image without caption", func(t *testing.T) {
+		source := []byte(`![](https://example.com/cat.png)`)
+		node := firstImage(parseMarkdown(source))
+
+		block, err := c.convertImage(node, source)
+
+		require.NoError(t, err)
+		result, ok := block.(*notionapi.ImageBlock)
+		require.True(t, ok)
+		assert.Empty(t, result.Image.Caption)
+	})
+}
+
+func TestConvertParagraphWithImages(t *testing.T) {
+	t.Run("splits text around images in order", func(t *testing.T) {
+		source := []byte("Before ![one](https://example.com/1.png) between ![two](https://example.com/2.png) after")
+		paragraph := parseMarkdown(source).FirstChild().(*ast.Paragraph)
+
+		result, err := (&Converter{}).convertParagraphWithImages(paragraph, source)
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 5)
+		assert.Equal(t, "Before ", result[0].GetRichTextString())
+		assert.Equal(t, "https://example.com/1.png", result[1].(*notionapi.ImageBlock).Image.External.URL)
+		assert.Equal(t, " between ", result[2].GetRichTextString())
+		assert.Equal(t, "https://example.com/2.png", result[3].(*notionapi.ImageBlock).Image.External.URL)
+		assert.Equal(t, " after", result[4].GetRichTextString())
+	})
+
+	t.Run("skips whitespace between images", func(t *testing.T) {
+		source := []byte("![one](https://example.com/1.png)\n![two](https://example.com/2.png)")
+		paragraph := parseMarkdown(source).FirstChild().(*ast.Paragraph)
+
+		result, err := (&Converter{}).convertParagraphWithImages(paragraph, source)
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 2)
+		assert.Equal(t, notionapi.BlockTypeImage, result[0].GetType())
+		assert.Equal(t, notionapi.BlockTypeImage, result[1].GetType())
+	})
+}
+
+func TestConvertNestedImages(t *testing.T) {
+	t.Run("splits images out of list items", func(t *testing.T) {
+		blocks := convertMarkdown(t, &Converter{}, "- ![pic](https://x/y.png)\n- see ![pic](https://x/z.png) here\n  - nested\n")
+
+		require.Len(t, blocks, 2)
+		first := blocks[0].(notionapi.BulletedListItemBlock)
+		assert.Empty(t, first.BulletedListItem.RichText)
+		require.Len(t, first.BulletedListItem.Children, 1)
+		assert.Equal(t, "https://x/y.png", first.BulletedListItem.Children[0].(*notionapi.ImageBlock).Image.External.URL)
+
+		second := blocks[1].(notionapi.BulletedListItemBlock)
+		assert.Equal(t, "see ", second.GetRichTextString())
+		children := second.BulletedListItem.Children
+		require.Len(t, children, 3)
+		assert.Equal(t, "https://x/z.png", children[0].(*notionapi.ImageBlock).Image.External.URL)
+		assert.Equal(t, " here", children[1].GetRichTextString())
+		assert.Equal(t, notionapi.BlockTypeBulletedListItem, children[2].GetType())
+	})
+
+	t.Run("splits images out of quotes", func(t *testing.T) {
+		blocks := convertMarkdown(t, &Converter{}, "> ![pic](https://x/y.png)\n")
+
+		require.Len(t, blocks, 1)
+		quote := blocks[0].(*notionapi.QuoteBlock)
+		assert.NotNil(t, quote.Quote.RichText)
+		assert.Empty(t, quote.Quote.RichText)
+		require.Len(t, quote.Quote.Children, 1)
+		assert.Equal(t, "https://x/y.png", quote.Quote.Children[0].(*notionapi.ImageBlock).Image.External.URL)
+	})
+
+	t.Run("keeps the link of linked images on the caption", func(t *testing.T) {
+		blocks := convertMarkdown(t, &Converter{}, "[![ci](https://x/b.svg)](https://ci) [![](https://x/c.svg) coverage](https://cov)\n")
+
+		require.Len(t, blocks, 3)
+		badge := blocks[0].(*notionapi.ImageBlock)
+		assert.Equal(t, "https://x/b.svg", badge.Image.External.URL)
+		assert.Equal(t, "ci", badge.Image.Caption[0].PlainText)
+		assert.Equal(t, &notionapi.Link{Url: "https://ci"}, badge.Image.Caption[0].Text.Link)
+
+		coverage := blocks[1].(*notionapi.ImageBlock)
+		assert.Equal(t, "https://cov", coverage.Image.Caption[0].PlainText)
+		assert.Equal(t, &notionapi.Link{Url: "https://cov"}, coverage.Image.Caption[0].Text.Link)
+
+		text := blocks[2].(*notionapi.ParagraphBlock)
+		assert.Equal(t, " coverage", text.GetRichTextString())
+		assert.Equal(t, &notionapi.Link{Url: "https://cov"}, text.Paragraph.RichText[0].Text.Link)
+	})
+}
+
+// uploaded records the files passed to an ImageUploader.
+type uploaded struct {
+	filename    string
+	contentType string
+	data        []byte
+}
+
+func TestConvertImageUpload(t *testing.T) {
+	newConverter := func(t *testing.T, uploads *[]uploaded) *Converter {
+		dir := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, "images"), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "images", "image1.png"), []byte("png bytes"), 0o644))
+
+		return &Converter{
+			MarkdownFilePath: filepath.Join(dir, "README.md"),
+			ImageUploader: func(filename, contentType string, data []byte) (string, error) {
+				*uploads = append(*uploads, uploaded{filename, contentType, data})
+				return "upload-id", nil
+			},
+		}
+	}
+
+	t.Run("uploads local image relative to the markdown file", func(t *testing.T) {
+		var uploads []uploaded
+		c := newConverter(t, &uploads)
+		source := []byte(`![screenshot](./images/image1.png)`)
+
+		result, err := c.convertImage(firstImage(parseMarkdown(source)), source)
+
+		require.NoError(t, err)
+		assert.Equal(t, []uploaded{{"image1.png", "image/png", []byte("png bytes")}}, uploads)
+		block, ok := result.(*UploadedImageBlock)
+		require.True(t, ok)
+		assert.Equal(t, notionapi.BlockTypeImage, block.Type)
+		assert.Equal(t, fileTypeFileUpload, block.Image.Type)
+		assert.Equal(t, "upload-id", block.Image.FileUpload.ID)
+		assert.Equal(t, "screenshot", block.Image.Caption[0].PlainText)
+	})
+
+	t.Run("uploads data URI image", func(t *testing.T) {
+		var uploads []uploaded
+		c := newConverter(t, &uploads)
+		source := []byte(`![dot](data:image/png;base64,cG5nIGJ5dGVz)`)
+
+		result, err := c.convertImage(firstImage(parseMarkdown(source)), source)
+
+		require.NoError(t, err)
+		assert.Equal(t, []uploaded{{"image.png", "image/png", []byte("png bytes")}}, uploads)
+		assert.IsType(t, &UploadedImageBlock{}, result)
+	})
+
+	t.Run("keeps remote image as external URL", func(t *testing.T) {
+		var uploads []uploaded
+		c := newConverter(t, &uploads)
+		source := []byte(`![logo](https://example.com/logo.png)`)
+
+		result, err := c.convertImage(firstImage(parseMarkdown(source)), source)
+
+		require.NoError(t, err)
+		assert.Empty(t, uploads)
+		assert.IsType(t, &notionapi.ImageBlock{}, result)
+	})
+
+	t.Run("returns error for missing local image", func(t *testing.T) {
+		var uploads []uploaded
+		c := newConverter(t, &uploads)
+		source := []byte(`![missing](./images/missing.png)`)
+
+		_, err := c.convertImage(firstImage(parseMarkdown(source)), source)
+
+		assert.Error(t, err)
+		assert.Empty(t, uploads)
+	})
+
+	t.Run("returns uploader errors", func(t *testing.T) {
+		var uploads []uploaded
+		c := newConverter(t, &uploads)
+		c.ImageUploader = func(string, string, []byte) (string, error) {
+			return "", errors.New("upload failed")
+		}
+		source := []byte(`![screenshot](./images/image1.png)`)
+
+		_, err := c.convertImage(firstImage(parseMarkdown(source)), source)
+
+		assert.ErrorContains(t, err, "upload failed")
+	})
+}
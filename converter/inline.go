@@ -0,0 +1,122 @@
+package converter
+
+import (
+	"github.com/jomei/notionapi"
+	"github.com/sioncojp/go-markdown-to-notion/chunk"
+	"github.com/yuin/goldmark/ast"
+	east "github.com/yuin/goldmark/extension/ast"
+)
+
+// inlineStyle holds the annotations and link inherited from the enclosing inline nodes.
+// It is passed by value, so each level of nesting works on its own copy like a stack frame.
+type inlineStyle struct {
+	annotations notionapi.Annotations
+	link        string
+}
+
+// convertStyledInline converts an inline node and its descendants to Notion rich text,
+// combining the annotations of every enclosing style node.
+func convertStyledInline(node ast.Node, source []byte, style inlineStyle) []notionapi.RichText {
+	switch n := node.(type) {
+	case *ast.Text:
+		return newStyledRichText(textWithLineBreak(n, source), style)
+	case *ast.String:
+		return newStyledRichText(string(n.Value), style)
+	case *ast.Emphasis:
+		if n.Level >= 2 {
+			style.annotations.Bold = true
+		} else {
+			style.annotations.Italic = true
+		}
+	case *ast.CodeSpan:
+		style.annotations.Code = true
+	case *east.Strikethrough:
+		style.annotations.Strikethrough = true
+	case *underlineNode:
+		style.annotations.Underline = true
+	case *highlightNode:
+		style.annotations.Color = notionapi.ColorYellowBackground
+	case *ast.Link:
+		style.link = string(n.Destination)
+		if richText := convertStyledChildren(n, source, style); richText != nil {
+			return richText
+		}
+		// If no content is found, use the URL as content
+		return newStyledRichText(style.link, style)
+	case *ast.AutoLink:
+		style.link = string(n.URL(source))
+		if n.AutoLinkType == ast.AutoLinkEmail {
+			style.link = "mailto:" + style.link
+		}
+		return newStyledRichText(string(n.Label(source)), style)
+	case *ast.Image:
+		// Images can't live inside rich text, so keep their alt text
+		return newStyledRichText(imageAltText(n, source), style)
+	case *east.FootnoteLink:
+		return newStyledRichText(footnoteMarker(n.Index), style)
+	case *east.FootnoteBacklink:
+		return nil
+	case *mathInlineNode:
+		return newEquationRichText(string(n.Segment.Value(source)), style)
+	case *ast.RawHTML:
+		return nil
+	}
+
+	return convertStyledChildren(node, source, style)
+}
+
+// textWithLineBreak returns the content of a text node followed by its line break.
+// A soft line break becomes a space and a hard line break becomes a newline.
+func textWithLineBreak(node *ast.Text, source []byte) string {
+	content := string(node.Segment.Value(source))
+	if node.HardLineBreak() {
+		return content + "\n"
+	}
+	if node.SoftLineBreak() {
+		return content + " "
+	}
+	return content
+}
+
+// hardenSoftLineBreaks turns every soft line break in a document into a hard line break,
+// so that wrapped lines are kept as newlines in Notion.
+func hardenSoftLineBreaks(document ast.Node) {
+	_ = ast.Walk(document, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if text, ok := n.(*ast.Text); ok && entering && text.SoftLineBreak() {
+			text.SetSoftLineBreak(false)
+			text.SetHardLineBreak(true)
+		}
+		return ast.WalkContinue, nil
+	})
+}
+
+// convertStyledChildren converts the children of an inline node with the given style.
+func convertStyledChildren(node ast.Node, source []byte, style inlineStyle) []notionapi.RichText {
+	var blocks []notionapi.RichText
+	for child := node.FirstChild(); child != nil; child = child.NextSibling() {
+		blocks = append(blocks, convertStyledInline(child, source, style)...)
+	}
+	return blocks
+}
+
+// newStyledRichText creates rich text for content with the given style.
+// Unstyled text has no annotations, matching chunk.RichText.
+func newStyledRichText(content string, style inlineStyle) []notionapi.RichText {
+	if content == "" {
+		return nil
+	}
+
+	var annotations *notionapi.Annotations
+	if style.annotations != (notionapi.Annotations{}) {
+		a := style.annotations
+		annotations = &a
+	}
+
+	blocks := chunk.RichText(content, annotations)
+	if style.link != "" {
+		for i := range blocks {
+			blocks[i].Text.Link = &notionapi.Link{Url: style.link}
+		}
+	}
+	return blocks
+}
@@ -0,0 +1,243 @@
+package converter
+
+import (
+	"testing"
+
+	"github.com/jomei/notionapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/text"
+)
+
+// inlineRun is a compact view of a rich text run for comparisons.
+type inlineRun struct {
+	content     string
+	annotations notionapi.Annotations
+	link        string
+}
+
+// convertInlineMarkdown converts the first paragraph of source to inline runs.
+func convertInlineMarkdown(source string) []inlineRun {
+	src := []byte(source)
+	richText := convertChildNodesToRichText(parseMarkdown(src).FirstChild(), src)
+
+	var runs []inlineRun
+	for _, rt := range richText {
+		run := inlineRun{content: rt.PlainText}
+		if rt.Annotations != nil {
+			run.annotations = *rt.Annotations
+		}
+		if rt.Text.Link != nil {
+			run.link = rt.Text.Link.Url
+		}
+		runs = append(runs, run)
+	}
+	return runs
+}
+
+func TestConvertStyledInline(t *testing.T) {
+	t.Run("combines bold and italic", func(t *testing.T) {
+		runs := convertInlineMarkdown("**bold *and italic***")
+
+		assert.Equal(t, []inlineRun{
+			{content: "bold ", annotations: notionapi.Annotations{Bold: true}},
+			{content: "and italic", annotations: notionapi.Annotations{Bold: true, Italic: true}},
+		}, runs)
+	})
+
+	t.Run("keeps bold inside link", func(t *testing.T) {
+		runs := convertInlineMarkdown("[**bold link**](https://example.com)")
+
+		assert.Equal(t, []inlineRun{
+			{content: "bold link", annotations: notionapi.Annotations{Bold: true}, link: "https://example.com"},
+		}, runs)
+	})
+
+	t.Run("keeps code inside bold", func(t *testing.T) {
+		runs := convertInlineMarkdown("**`code`**")
+
+		assert.Equal(t, []inlineRun{
+			{content: "code", annotations: notionapi.Annotations{Bold: true, Code: true}},
+		}, runs)
+	})
+
+	t.Run("combines bold, italic, code and link", func(t *testing.T) {
+		runs := convertInlineMarkdown("***[`all`](https://example.com)***")
+
+		assert.Equal(t, []inlineRun{
+			{content: "all", annotations: notionapi.Annotations{Bold: true, Italic: true, Code: true}, link: "https://example.com"},
+		}, runs)
+	})
+
+	t.Run("restores outer style after nested span", func(t *testing.T) {
+		runs := convertInlineMarkdown("*a **b** c* d")
+
+		assert.Equal(t, []inlineRun{
+			{content: "a ", annotations: notionapi.Annotations{Italic: true}},
+			{content: "b", annotations: notionapi.Annotations{Italic: true, Bold: true}},
+			{content: " c", annotations: notionapi.Annotations{Italic: true}},
+			{content: " d"},
+		}, runs)
+	})
+
+	t.Run("combines extended styles", func(t *testing.T) {
+		runs := convertInlineMarkdown("~~**==all==**~~")
+
+		assert.Equal(t, []inlineRun{
+			{content: "all", annotations: notionapi.Annotations{Strikethrough: true, Bold: true, Color: notionapi.ColorYellowBackground}},
+		}, runs)
+	})
+
+	t.Run("uses URL as content of empty link", func(t *testing.T) {
+		runs := convertInlineMarkdown("[](https://example.com)")
+
+		assert.Equal(t, []inlineRun{
+			{content: "https://example.com", link: "https://example.com"},
+		}, runs)
+	})
+
+	t.Run("converts autolinks", func(t *testing.T) {
+		runs := convertInlineMarkdown("<https://example.com>")
+
+		assert.Equal(t, []inlineRun{
+			{content: "https://example.com", link: "https://example.com"},
+		}, runs)
+	})
+}
+
+func TestConvertInlineNode(t *testing.T) {
+	t.Run("converts emphasis to italic", func(t *testing.T) {
+		source := []byte("*italic text*")
+		node := &ast.Emphasis{Level: 1}
+		node.AppendChild(node, ast.NewTextSegment(text.NewSegment(1, 12)))
+
+		richText := convertInlineNode(node, source)
+
+		assert.Len(t, richText, 1)
+		assert.Equal(t, "italic text", richText[0].PlainText)
+		assert.Equal(t, &notionapi.Annotations{Italic: true}, richText[0].Annotations)
+	})
+
+	t.Run("converts strong emphasis to bold", func(t *testing.T) {
+		source := []byte("**bold text**")
+		node := &ast.Emphasis{Level: 2}
+		node.AppendChild(node, ast.NewTextSegment(text.NewSegment(2, 11)))
+
+		richText := convertInlineNode(node, source)
+
+		assert.Len(t, richText, 1)
+		assert.Equal(t, "bold text", richText[0].PlainText)
+		assert.Equal(t, &notionapi.Annotations{Bold: true}, richText[0].Annotations)
+	})
+
+	t.Run("converts code span to code", func(t *testing.T) {
+		source := []byte("`code text`")
+		node := &ast.CodeSpan{}
+		node.AppendChild(node, ast.NewTextSegment(text.NewSegment(1, 10)))
+
+		richText := convertInlineNode(node, source)
+
+		assert.Len(t, richText, 1)
+		assert.Equal(t, "code text", richText[0].PlainText)
+		assert.Equal(t, &notionapi.Annotations{Code: true}, richText[0].Annotations)
+	})
+
+	t.Run("handles style nodes with no content", func(t *testing.T) {
+		assert.Nil(t, convertInlineNode(&ast.Emphasis{Level: 1}, nil))
+		assert.Nil(t, convertInlineNode(&ast.Emphasis{Level: 2}, nil))
+		assert.Nil(t, convertInlineNode(&ast.CodeSpan{}, nil))
+	})
+
+	t.Run("converts link with content", func(t *testing.T) {
+		source := []byte("[Link text](https://example.com \"Link title\")")
+		node := ast.NewLink()
+		node.Destination = []byte("https://example.com")
+		node.Title = []byte("Link title")
+		node.AppendChild(node, ast.NewTextSegment(text.NewSegment(1, 10)))
+
+		richText := convertInlineNode(node, source)
+
+		assert.Len(t, richText, 1)
+		assert.Equal(t, "Link text", richText[0].PlainText)
+		assert.Equal(t, &notionapi.Link{Url: "https://example.com"}, richText[0].Text.Link)
+	})
+
+	t.Run("uses URL as content of link without text", func(t *testing.T) {
+		node := ast.NewLink()
+		node.Destination = []byte("https://example.com")
+
+		richText := convertInlineNode(node, []byte("[](https://example.com)"))
+
+		assert.Len(t, richText, 1)
+		assert.Equal(t, "https://example.com", richText[0].PlainText)
+		assert.Equal(t, &notionapi.Link{Url: "https://example.com"}, richText[0].Text.Link)
+	})
+
+	t.Run("keeps text of link with empty destination", func(t *testing.T) {
+		node := ast.NewLink()
+		node.AppendChild(node, ast.NewTextSegment(text.NewSegment(1, 10)))
+
+		richText := convertInlineNode(node, []byte("[Link text]()"))
+
+		assert.Len(t, richText, 1)
+		assert.Equal(t, "Link text", richText[0].PlainText)
+		assert.Nil(t, richText[0].Text.Link)
+	})
+
+	t.Run("ignores block nodes without inline content", func(t *testing.T) {
+		assert.Nil(t, convertInlineNode(&ast.Paragraph{}, nil))
+	})
+}
+
+// findRichText returns the first rich text whose plain text matches content.
+func findRichText(t *testing.T, richText []notionapi.RichText, content string) notionapi.RichText {
+	t.Helper()
+	for _, rt := range richText {
+		if rt.PlainText == content {
+			return rt
+		}
+	}
+	t.Fatalf("rich text %q not found in %v", content, richText)
+	return notionapi.RichText{}
+}
+
+func TestConvertExtendedStyles(t *testing.T) {
+	t.Run("converts strikethrough, underline and highlight in a paragraph", func(t *testing.T) {
+		source := []byte("~~strike~~ ++under++ <u>html under</u> ==mark==")
+		paragraph := parseMarkdown(source).FirstChild()
+
+		richText := convertChildNodesToRichText(paragraph, source)
+
+		assert.True(t, findRichText(t, richText, "strike").Annotations.Strikethrough)
+		assert.True(t, findRichText(t, richText, "under").Annotations.Underline)
+		assert.True(t, findRichText(t, richText, "html under").Annotations.Underline)
+		assert.Equal(t, notionapi.ColorYellowBackground, findRichText(t, richText, "mark").Annotations.Color)
+	})
+
+	t.Run("converts styles in list items, table cells and quotes", func(t *testing.T) {
+		source := []byte("- ~~done~~\n\n| A |\n|---|\n| ==hot== |\n\n> ++note++\n")
+		document := parseMarkdown(source)
+
+		list := document.FirstChild()
+		table := list.NextSibling()
+		quote := table.NextSibling()
+
+		assert.True(t, findRichText(t, convertChildNodesToRichText(list, source), "done").Annotations.Strikethrough)
+		assert.Equal(t, notionapi.ColorYellowBackground, findRichText(t, convertChildNodesToRichText(table, source), "hot").Annotations.Color)
+		assert.True(t, findRichText(t, convertChildNodesToRichText(quote, source), "note").Annotations.Underline)
+	})
+
+	t.Run("leaves single delimiters as text", func(t *testing.T) {
+		source := []byte("a + b = c")
+		paragraph := parseMarkdown(source).FirstChild()
+
+		richText := convertChildNodesToRichText(paragraph, source)
+
+		var content string
+		for _, rt := range richText {
+			content += rt.PlainText
+			assert.Nil(t, rt.Annotations)
+		}
+		assert.Equal(t, "a + b = c", content)
+	})
+}
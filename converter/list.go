@@ -4,6 +4,7 @@ import (
   "github.com/jomei/notionapi"
   "github.com/sioncojp/go-markdown-to-notion/chunk"
   "github.com/yuin/goldmark/ast"
+  east "github.com/yuin/goldmark/extension/ast"
 )
 
 // isList checks if a node is a list.
@@ -19,9 +20,11 @@ func isListItem(node ast.Node) bool {
 }
 
 // convertList converts a list node to Notion list blocks.
-func convertList(node *ast.List, source []byte) []notionapi.Block {
+// The blocks after the first paragraph of each item are converted with the normal block pipeline
+// and become the children of the item.
+func (c *Converter) convertList(node *ast.List, source []byte) ([]notionapi.Block, error) {
   if node == nil {
-    return nil
+    return nil, nil
   }
 
   var items []notionapi.Block
@@ -32,28 +35,60 @@ func convertList(node *ast.List, source []byte) []notionapi.Block {
       continue
     }
 
-    var nestedBlocks []notionapi.Block
-    for grandChild := listItem.FirstChild(); grandChild != nil; grandChild = grandChild.NextSibling() {
-      if nestedList, ok := grandChild.(*ast.List); ok {
-        nestedListBlocks := convertList(nestedList, source)
-        nestedBlocks = append(nestedBlocks, nestedListBlocks...)
-      }
+    nestedBlocks, err := c.convertSiblings(listItemBodyStart(listItem), source)
+    if err != nil {
+      return nil, err
     }
 
-    if node.IsOrdered() {
-      item := convertNumberListItem(child.(*ast.ListItem), source, nestedBlocks)
-      if item != nil {
-        items = append(items, item)
+    // Images in the item text are split out as the first children of the item
+    var itemText []notionapi.RichText
+    hasImages := isListItemText(listItem.FirstChild()) && hasImage(listItem.FirstChild())
+    if hasImages {
+      var imageBlocks []notionapi.Block
+      itemText, imageBlocks, err = c.convertTextWithImages(listItem.FirstChild(), source)
+      if err != nil {
+        return nil, err
       }
+      nestedBlocks = append(imageBlocks, nestedBlocks...)
+    }
+
+    var item notionapi.Block
+    if checkBox := taskCheckBox(listItem); checkBox != nil {
+      item = convertToDoListItem(listItem, source, nestedBlocks, checkBox.IsChecked)
+    } else if node.IsOrdered() {
+      item = convertNumberListItem(listItem, source, nestedBlocks)
     } else {
-      item := convertListItem(child.(*ast.ListItem), source, nestedBlocks)
-      if item != nil {
-        items = append(items, item)
-      }
+      item = convertListItem(listItem, source, nestedBlocks)
+    }
+    if item == nil {
+      continue
     }
+    if hasImages {
+      item = withListItemText(item, itemText)
+    }
+    items = append(items, item)
   }
 
-  return items
+  return items, nil
+}
+
+// listItemBodyStart returns the first child of a list item that is converted to a child block.
+// A leading paragraph becomes the rich text of the item itself.
+func listItemBodyStart(node *ast.ListItem) ast.Node {
+  first := node.FirstChild()
+  if isListItemText(first) {
+    return first.NextSibling()
+  }
+  return first
+}
+
+// isListItemText checks if a node is the paragraph (or the text block of a tight list) holding the text of a list item.
+func isListItemText(node ast.Node) bool {
+  switch node.(type) {
+  case *ast.Paragraph, *ast.TextBlock:
+    return true
+  }
+  return false
 }
 
 // convertListItem ... converts a bulleted list item to a Notion block.
@@ -66,10 +101,15 @@ func convertListItem(node *ast.ListItem, source []byte, children []notionapi.Blo
   richText := convertListItemContent(node, source)
 
   // Skip empty list items
-  if len(richText) == 0 {
+  if len(richText) == 0 && len(children) == 0 {
     return nil
   }
 
+  // Notion requires rich text even when the item starts with a child block
+  if richText == nil {
+    richText = []notionapi.RichText{}
+  }
+
   // Create a bulleted list item block by default
   block := notionapi.BulletedListItemBlock{
     BasicBlock: notionapi.BasicBlock{
@@ -95,10 +135,15 @@ func convertNumberListItem(node *ast.ListItem, source []byte, children []notiona
   richText := convertListItemContent(node, source)
 
   // Skip empty list items
-  if len(richText) == 0 {
+  if len(richText) == 0 && len(children) == 0 {
     return nil
   }
 
+  // Notion requires rich text even when the item starts with a child block
+  if richText == nil {
+    richText = []notionapi.RichText{}
+  }
+
   // Create a numbered list item block
   block := notionapi.NumberedListItemBlock{
     BasicBlock: notionapi.BasicBlock{
@@ -114,6 +159,65 @@ func convertNumberListItem(node *ast.ListItem, source []byte, children []notiona
   return block
 }
 
+// withListItemText replaces the rich text of a list item block built by convertList.
+func withListItemText(item notionapi.Block, richText []notionapi.RichText) notionapi.Block {
+  switch b := item.(type) {
+  case notionapi.BulletedListItemBlock:
+    b.BulletedListItem.RichText = richText
+    return b
+  case notionapi.NumberedListItemBlock:
+    b.NumberedListItem.RichText = richText
+    return b
+  case notionapi.ToDoBlock:
+    b.ToDo.RichText = richText
+    return b
+  }
+  return item
+}
+
+// taskCheckBox returns the task list check box of a list item, or nil if the item is not a task.
+func taskCheckBox(node *ast.ListItem) *east.TaskCheckBox {
+  if node == nil || node.FirstChild() == nil {
+    return nil
+  }
+
+  checkBox, ok := node.FirstChild().FirstChild().(*east.TaskCheckBox)
+  if !ok {
+    return nil
+  }
+  return checkBox
+}
+
+// convertToDoListItem ... converts a task list item node to a Notion to_do block.
+func convertToDoListItem(node *ast.ListItem, source []byte, children []notionapi.Block, checked bool) notionapi.Block {
+  if node == nil {
+    return nil
+  }
+
+  // Get rich text content
+  richText := convertListItemContent(node, source)
+
+  // Keep empty tasks so they can be filled in Notion
+  if richText == nil {
+    richText = []notionapi.RichText{}
+  }
+
+  // Create a to_do block
+  block := notionapi.ToDoBlock{
+    BasicBlock: notionapi.BasicBlock{
+      Object: notionapi.ObjectTypeBlock,
+      Type:   notionapi.BlockTypeToDo,
+    },
+    ToDo: notionapi.ToDo{
+      RichText: richText,
+      Children: children,
+      Checked:  checked,
+    },
+  }
+
+  return block
+}
+
 // convertListItemContent ... converts the content of a list item to Notion rich text.
 func convertListItemContent(node *ast.ListItem, source []byte) []notionapi.RichText {
   if node == nil {
@@ -130,20 +234,9 @@ func convertListItemContent(node *ast.ListItem, source []byte) []notionapi.RichT
     }
   }
 
-  // Normal processing for actual markdown content
-  var blocks []notionapi.RichText
-  for child := node.FirstChild(); child != nil; child = child.NextSibling() {
-    // Skip nested lists
-    if _, ok := child.(*ast.List); ok {
-      continue
-    }
-
-    // Convert other content
-    richText := convertChildNodesToRichText(child, source)
-    if richText != nil {
-      blocks = append(blocks, richText...)
-    }
+  // Only the leading paragraph is the item text, the rest are child blocks
+  if !isListItemText(node.FirstChild()) {
+    return nil
   }
-
-  return blocks
+  return convertChildNodesToRichText(node.FirstChild(), source)
 }
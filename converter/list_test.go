@@ -59,7 +59,8 @@ func TestConvertList(t *testing.T) {
 		source := []byte("Item 1")
 		
 		// Convert and test
-		result := convertList(list, source)
+		result, err := (&Converter{}).convertList(list, source)
+		assert.NoError(t, err)
 		assert.Equal(t, 1, len(result))
 		
 		// Check the type and content
@@ -95,7 +96,8 @@ func TestConvertList(t *testing.T) {
 		source := []byte("Item 1")
 		
 		// Convert and test
-		result := convertList(list, source)
+		result, err := (&Converter{}).convertList(list, source)
+		assert.NoError(t, err)
 		assert.Equal(t, 1, len(result))
 		
 		// Check the type and content
@@ -105,7 +107,8 @@ func TestConvertList(t *testing.T) {
 	})
 	
 	t.Run("returns nil for nil node", func(t *testing.T) {
-		result := convertList(nil, []byte{})
+		result, err := (&Converter{}).convertList(nil, []byte{})
+		assert.NoError(t, err)
 		assert.Nil(t, result)
 	})
 	
@@ -135,7 +138,87 @@ func TestConvertList(t *testing.T) {
 		source := []byte("")
 		
 		// Convert and test
-		result := convertList(list, source)
+		result, err := (&Converter{}).convertList(list, source)
+		assert.NoError(t, err)
 		assert.Equal(t, 0, len(result))
 	})
 }
+
+func TestConvertTaskList(t *testing.T) {
+	t.Run("converts task list items to to_do blocks", func(t *testing.T) {
+		source := []byte("- [ ] open task\n- [x] done task\n")
+		list := parseMarkdown(source).FirstChild().(*ast.List)
+
+		result, err := (&Converter{}).convertList(list, source)
+		assert.NoError(t, err)
+
+		assert.Len(t, result, 2)
+		open, ok := result[0].(notionapi.ToDoBlock)
+		assert.True(t, ok)
+		assert.Equal(t, notionapi.BlockTypeToDo, open.Type)
+		assert.Equal(t, "open task", open.GetRichTextString())
+		assert.False(t, open.ToDo.Checked)
+
+		done, ok := result[1].(notionapi.ToDoBlock)
+		assert.True(t, ok)
+		assert.Equal(t, "done task", done.GetRichTextString())
+		assert.True(t, done.ToDo.Checked)
+	})
+
+	t.Run("keeps nested children of task list items", func(t *testing.T) {
+		source := []byte("- [x] parent\n  - [ ] child\n  - plain child\n")
+		list := parseMarkdown(source).FirstChild().(*ast.List)
+
+		result, err := (&Converter{}).convertList(list, source)
+		assert.NoError(t, err)
+
+		assert.Len(t, result, 1)
+		parent := result[0].(notionapi.ToDoBlock)
+		assert.True(t, parent.ToDo.Checked)
+		assert.Len(t, parent.ToDo.Children, 2)
+		assert.IsType(t, notionapi.ToDoBlock{}, parent.ToDo.Children[0])
+		assert.IsType(t, notionapi.BulletedListItemBlock{}, parent.ToDo.Children[1])
+	})
+
+	t.Run("keeps ordinary items as bulleted list items", func(t *testing.T) {
+		source := []byte("- [link](https://example.com)\n")
+		list := parseMarkdown(source).FirstChild().(*ast.List)
+
+		result, err := (&Converter{}).convertList(list, source)
+		assert.NoError(t, err)
+
+		assert.Len(t, result, 1)
+		assert.IsType(t, notionapi.BulletedListItemBlock{}, result[0])
+	})
+}
+
+func TestConvertListItemChildren(t *testing.T) {
+	t.Run("converts later blocks of a loose item to children", func(t *testing.T) {
+		markdown := "- First paragraph\n\n  Second paragraph\n\n  ```go\n  fmt.Println()\n  ```\n\n  > quoted\n\n  - nested\n- Next item\n"
+		blocks := convertMarkdown(t, &Converter{}, markdown)
+
+		assert.Len(t, blocks, 2)
+		item := blocks[0].(notionapi.BulletedListItemBlock)
+		assert.Equal(t, "First paragraph", item.GetRichTextString())
+
+		children := item.BulletedListItem.Children
+		assert.Len(t, children, 4)
+		assert.Equal(t, notionapi.BlockTypeParagraph, children[0].GetType())
+		assert.Equal(t, "Second paragraph", children[0].GetRichTextString())
+		assert.Equal(t, notionapi.BlockTypeCode, children[1].GetType())
+		assert.Equal(t, notionapi.BlockTypeQuote, children[2].GetType())
+		assert.Equal(t, notionapi.BlockTypeBulletedListItem, children[3].GetType())
+
+		assert.Equal(t, "Next item", blocks[1].GetRichTextString())
+	})
+
+	t.Run("keeps item starting with a code block", func(t *testing.T) {
+		blocks := convertMarkdown(t, &Converter{}, "1. ```sh\n   make build\n   ```\n")
+
+		assert.Len(t, blocks, 1)
+		item := blocks[0].(notionapi.NumberedListItemBlock)
+		assert.Empty(t, item.NumberedListItem.RichText)
+		assert.Len(t, item.NumberedListItem.Children, 1)
+		assert.Equal(t, notionapi.BlockTypeCode, item.NumberedListItem.Children[0].GetType())
+	})
+}
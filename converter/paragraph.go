@@ -23,25 +23,9 @@ func convertParagraph(node *ast.Paragraph, source []byte) *notionapi.ParagraphBl
 
 	// Process child nodes to handle inline elements like links, emphasis, etc.
 	for child := node.FirstChild(); child != nil; child = child.NextSibling() {
-		// Handle different types of inline elements
-		if isLink(child) {
-			// Convert link node
-			linkRichText := convertLink(child.(*ast.Link), source)
-			if linkRichText != nil {
-				richTextBlocks = append(richTextBlocks, linkRichText...)
-			}
-		} else if isEmphasis(child) || isStrong(child) || isCodeSpan(child) {
-			// Convert style nodes (emphasis, strong, code span)
-			styleRichText := convertStyle(child, source)
-			if styleRichText != nil {
-				richTextBlocks = append(richTextBlocks, styleRichText...)
-			}
-		} else if text, ok := child.(*ast.Text); ok {
-			// Convert plain text
-			content := string(text.Segment.Value(source))
-			if content != "" {
-				richTextBlocks = append(richTextBlocks, chunk.RichText(content, nil)...)
-			}
+		childRichText := convertInlineNode(child, source)
+		if childRichText != nil {
+			richTextBlocks = append(richTextBlocks, childRichText...)
 		}
 	}
 
@@ -57,13 +41,18 @@ func convertParagraph(node *ast.Paragraph, source []byte) *notionapi.ParagraphBl
 	}
 
 	// Create and return the paragraph block
+	return newParagraphBlock(richTextBlocks)
+}
+
+// newParagraphBlock creates a Notion paragraph block from rich text.
+func newParagraphBlock(richText []notionapi.RichText) *notionapi.ParagraphBlock {
 	return &notionapi.ParagraphBlock{
 		BasicBlock: notionapi.BasicBlock{
 			Object: notionapi.ObjectTypeBlock,
 			Type:   notionapi.BlockTypeParagraph,
 		},
 		Paragraph: notionapi.Paragraph{
-			RichText: richTextBlocks,
+			RichText: richText,
 			Color:    "default",
 		},
 	}
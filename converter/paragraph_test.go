@@ -235,3 +235,39 @@ func TestConvertParagraph(t *testing.T) {
 		assert.True(t, hasCode, "Expected paragraph to contain code text")
 	})
 }
+
+func TestConvertParagraphLineBreaks(t *testing.T) {
+	t.Run("soft line break becomes a space", func(t *testing.T) {
+		source := []byte("line one\nline two")
+		paragraph := parseMarkdown(source).FirstChild().(*ast.Paragraph)
+
+		paragraphBlock := convertParagraph(paragraph, source)
+
+		assert.Equal(t, "line one line two", paragraphBlock.GetRichTextString())
+	})
+
+	t.Run("hard line breaks become newlines", func(t *testing.T) {
+		source := []byte("backslash\\\ntwo spaces  \nend")
+		paragraph := parseMarkdown(source).FirstChild().(*ast.Paragraph)
+
+		paragraphBlock := convertParagraph(paragraph, source)
+
+		assert.Equal(t, "backslash\ntwo spaces\nend", paragraphBlock.GetRichTextString())
+	})
+
+	t.Run("soft line break becomes a newline when configured", func(t *testing.T) {
+		blocks := convertMarkdown(t, &Converter{SoftLineBreakAsNewline: true}, "line one\nline two\n\n> quoted one\n> quoted two\n")
+
+		assert.Equal(t, "line one\nline two", blocks[0].GetRichTextString())
+		assert.Equal(t, "quoted one\nquoted two", blocks[1].GetRichTextString())
+	})
+
+	t.Run("line breaks are kept inside styles", func(t *testing.T) {
+		source := []byte("**bold\nwrapped**")
+		paragraph := parseMarkdown(source).FirstChild().(*ast.Paragraph)
+
+		paragraphBlock := convertParagraph(paragraph, source)
+
+		assert.Equal(t, "bold wrapped", paragraphBlock.GetRichTextString())
+	})
+}
@@ -0,0 +1,117 @@
+package converter
+
+import (
+	"github.com/jomei/notionapi"
+	"github.com/sioncojp/go-markdown-to-notion/chunk"
+)
+
+// normalizeBlocks merges the adjacent rich text runs of blocks and their descendants in place.
+// goldmark splits text at entities, escapes and line breaks, so converted blocks often hold
+// runs that only spend the rich text element limit.
+// Headings and list items are built as values, so they are replaced by their normalized copy.
+func normalizeBlocks(blocks []notionapi.Block) {
+	for i, block := range blocks {
+		switch b := block.(type) {
+		case *notionapi.ParagraphBlock:
+			b.Paragraph.RichText = mergeRichText(b.Paragraph.RichText)
+		case *notionapi.Heading1Block:
+			b.Heading1.RichText = mergeRichText(b.Heading1.RichText)
+		case notionapi.Heading1Block:
+			b.Heading1.RichText = mergeRichText(b.Heading1.RichText)
+			blocks[i] = b
+		case *notionapi.Heading2Block:
+			b.Heading2.RichText = mergeRichText(b.Heading2.RichText)
+		case notionapi.Heading2Block:
+			b.Heading2.RichText = mergeRichText(b.Heading2.RichText)
+			blocks[i] = b
+		case *notionapi.Heading3Block:
+			b.Heading3.RichText = mergeRichText(b.Heading3.RichText)
+		case notionapi.Heading3Block:
+			b.Heading3.RichText = mergeRichText(b.Heading3.RichText)
+			blocks[i] = b
+		case *notionapi.CalloutBlock:
+			b.Callout.RichText = mergeRichText(b.Callout.RichText)
+		case *notionapi.QuoteBlock:
+			b.Quote.RichText = mergeRichText(b.Quote.RichText)
+		case *notionapi.BulletedListItemBlock:
+			b.BulletedListItem.RichText = mergeRichText(b.BulletedListItem.RichText)
+		case notionapi.BulletedListItemBlock:
+			b.BulletedListItem.RichText = mergeRichText(b.BulletedListItem.RichText)
+			blocks[i] = b
+		case *notionapi.NumberedListItemBlock:
+			b.NumberedListItem.RichText = mergeRichText(b.NumberedListItem.RichText)
+		case notionapi.NumberedListItemBlock:
+			b.NumberedListItem.RichText = mergeRichText(b.NumberedListItem.RichText)
+			blocks[i] = b
+		case *notionapi.ToDoBlock:
+			b.ToDo.RichText = mergeRichText(b.ToDo.RichText)
+		case notionapi.ToDoBlock:
+			b.ToDo.RichText = mergeRichText(b.ToDo.RichText)
+			blocks[i] = b
+		case *notionapi.ToggleBlock:
+			b.Toggle.RichText = mergeRichText(b.Toggle.RichText)
+		case *notionapi.CodeBlock:
+			b.Code.RichText = mergeRichText(b.Code.RichText)
+			b.Code.Caption = mergeRichText(b.Code.Caption)
+		case *notionapi.ImageBlock:
+			b.Image.Caption = mergeRichText(b.Image.Caption)
+		case *UploadedImageBlock:
+			b.Image.Caption = mergeRichText(b.Image.Caption)
+		case *notionapi.TableRowBlock:
+			for i, cell := range b.TableRow.Cells {
+				b.TableRow.Cells[i] = mergeRichText(cell)
+			}
+		}
+
+		normalizeBlocks(chunk.Children(block))
+	}
+}
+
+// mergeRichText merges adjacent text runs with the same annotations and link,
+// as long as the merged content stays within chunk.CharacterLimit, and drops empty runs.
+func mergeRichText(richText []notionapi.RichText) []notionapi.RichText {
+	// Keep an empty rich text empty rather than nil, since blocks like quotes must send it
+	merged := richText[:0:0]
+	for _, rt := range richText {
+		if rt.Text != nil && rt.Text.Content == "" {
+			continue
+		}
+
+		if last := len(merged) - 1; last >= 0 && canMergeRichText(merged[last], rt) {
+			content := merged[last].Text.Content + rt.Text.Content
+			// Copy the text so that runs shared with other blocks are left as they are
+			merged[last].Text = &notionapi.Text{Content: content, Link: merged[last].Text.Link}
+			merged[last].PlainText = content
+			continue
+		}
+		merged = append(merged, rt)
+	}
+	return merged
+}
+
+// canMergeRichText checks if two rich text runs look the same and fit in one run.
+func canMergeRichText(a, b notionapi.RichText) bool {
+	if a.Type != notionapi.ObjectTypeText || b.Type != notionapi.ObjectTypeText || a.Text == nil || b.Text == nil {
+		return false
+	}
+	if annotationsOf(a) != annotationsOf(b) || linkOf(a) != linkOf(b) || a.Href != b.Href {
+		return false
+	}
+	return chunk.Length(a.Text.Content)+chunk.Length(b.Text.Content) <= chunk.CharacterLimit
+}
+
+// annotationsOf returns the annotations of a rich text run, where no annotations mean the default ones.
+func annotationsOf(rt notionapi.RichText) notionapi.Annotations {
+	if rt.Annotations == nil {
+		return notionapi.Annotations{}
+	}
+	return *rt.Annotations
+}
+
+// linkOf returns the URL a rich text run links to, or an empty string.
+func linkOf(rt notionapi.RichText) string {
+	if rt.Text.Link == nil {
+		return ""
+	}
+	return rt.Text.Link.Url
+}
@@ -0,0 +1,105 @@
+package converter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jomei/notionapi"
+	"github.com/sioncojp/go-markdown-to-notion/chunk"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeRichText(t *testing.T) {
+	bold := &notionapi.Annotations{Bold: true}
+
+	t.Run("merges runs with the same annotations and link", func(t *testing.T) {
+		richText := append(chunk.RichText("a", nil), chunk.RichText("b", &notionapi.Annotations{})...)
+		richText = append(richText, chunk.RichText("c", bold)...)
+		richText = append(richText, chunk.RichText("d", bold)...)
+		richText = append(richText, chunk.RichTextWithLink("e", "https://example.com")...)
+		richText = append(richText, chunk.RichTextWithLink("f", "https://example.com")...)
+		richText = append(richText, chunk.RichTextWithLink("g", "https://example.org")...)
+
+		merged := mergeRichText(richText)
+
+		var contents []string
+		for _, rt := range merged {
+			contents = append(contents, rt.PlainText)
+		}
+		assert.Equal(t, []string{"ab", "cd", "ef", "g"}, contents)
+		assert.Equal(t, bold, merged[1].Annotations)
+		assert.Equal(t, "https://example.com", merged[2].Text.Link.Url)
+	})
+
+	t.Run("drops empty runs", func(t *testing.T) {
+		richText := []notionapi.RichText{
+			{Type: notionapi.ObjectTypeText, Text: &notionapi.Text{Content: "a"}, PlainText: "a", Annotations: bold},
+			{Type: notionapi.ObjectTypeText, Text: &notionapi.Text{}},
+			{Type: notionapi.ObjectTypeText, Text: &notionapi.Text{Content: "b"}, PlainText: "b", Annotations: bold},
+		}
+
+		merged := mergeRichText(richText)
+
+		assert.Len(t, merged, 1)
+		assert.Equal(t, "ab", merged[0].PlainText)
+	})
+
+	t.Run("keeps equations apart", func(t *testing.T) {
+		richText := append(chunk.RichText("area ", nil), newEquationRichText("x^2", inlineStyle{})...)
+		richText = append(richText, chunk.RichText(" here", nil)...)
+
+		assert.Len(t, mergeRichText(richText), 3)
+	})
+
+	t.Run("respects the character limit", func(t *testing.T) {
+		richText := append(chunk.RichText(strings.Repeat("a", 1500), nil), chunk.RichText(strings.Repeat("b", 1000), nil)...)
+
+		assert.Len(t, mergeRichText(richText), 2)
+	})
+
+	t.Run("leaves the original runs untouched", func(t *testing.T) {
+		richText := append(chunk.RichText("a", nil), chunk.RichText("b", nil)...)
+
+		mergeRichText(richText)
+
+		assert.Equal(t, "a", richText[0].Text.Content)
+	})
+
+	t.Run("keeps empty rich text non-nil", func(t *testing.T) {
+		assert.NotNil(t, mergeRichText([]notionapi.RichText{}))
+		assert.Nil(t, mergeRichText(nil))
+	})
+}
+
+func TestNormalizeBlocks(t *testing.T) {
+	t.Run("merges text split at line breaks", func(t *testing.T) {
+		blocks := convertMarkdown(t, &Converter{}, "first line\nsecond line\\\nthird line\n")
+
+		paragraph := blocks[0].(*notionapi.ParagraphBlock)
+		assert.Len(t, paragraph.Paragraph.RichText, 1)
+		assert.Equal(t, "first line second line\nthird line", paragraph.GetRichTextString())
+	})
+
+	t.Run("merges headings", func(t *testing.T) {
+		blocks := convertMarkdown(t, &Converter{}, "# a <b>x</b> c\n\n## a <b>x</b> c\n\n### a <b>x</b> c\n")
+
+		assert.Len(t, blocks[0].(notionapi.Heading1Block).Heading1.RichText, 1)
+		assert.Len(t, blocks[1].(notionapi.Heading2Block).Heading2.RichText, 1)
+		assert.Len(t, blocks[2].(notionapi.Heading3Block).Heading3.RichText, 1)
+		assert.Equal(t, "a x c", blocks[0].GetRichTextString())
+	})
+
+	t.Run("merges nested children and table cells", func(t *testing.T) {
+		blocks := convertMarkdown(t, &Converter{}, "- a\n  b\n  - c\n    d\n\n| a<span>b</span> |\n| --- |\n| c<span>d</span> |\n")
+
+		item := blocks[0].(notionapi.BulletedListItemBlock)
+		assert.Len(t, item.BulletedListItem.RichText, 1)
+		child := item.BulletedListItem.Children[0].(notionapi.BulletedListItemBlock)
+		assert.Len(t, child.BulletedListItem.RichText, 1)
+
+		table := blocks[1].(*notionapi.TableBlock)
+		for _, row := range table.Table.Children {
+			assert.Len(t, row.(*notionapi.TableRowBlock).TableRow.Cells[0], 1)
+		}
+	})
+}
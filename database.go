@@ -0,0 +1,292 @@
+package main
+
+import (
+  "context"
+  "errors"
+  "fmt"
+  "log"
+  "strings"
+  "time"
+
+  "github.com/jomei/notionapi"
+  "github.com/sioncojp/go-markdown-to-notion/chunk"
+  "github.com/sioncojp/go-markdown-to-notion/converter"
+)
+
+// errUnsupportedProperty ... Property type that front matter values can't be converted to
+var errUnsupportedProperty = errors.New("unsupported property type")
+
+// dateProperty ... Date property value, keeping date-only values without a time which notionapi.Date can't
+type dateProperty struct {
+  Type notionapi.PropertyType `json:"type"`
+  Date dateValue              `json:"date"`
+}
+
+// dateValue ... Start of a date property value
+type dateValue struct {
+  Start string `json:"start"`
+}
+
+// GetID ... Implement notionapi.Property
+func (p dateProperty) GetID() string {
+  return ""
+}
+
+// GetType ... Implement notionapi.Property
+func (p dateProperty) GetType() notionapi.PropertyType {
+  return notionapi.PropertyTypeDate
+}
+
+// PublishToDatabase ... Create a page in a Notion database with properties from the front matter and insert blocks into it
+func (n *Notion) PublishToDatabase(ctx context.Context, databaseID string, title string, frontMatter converter.FrontMatter, blocks []notionapi.Block) (*notionapi.Page, error) {
+  database, err := n.Client.Database.Get(ctx, notionapi.DatabaseID(databaseID))
+  if err != nil {
+    return nil, fmt.Errorf("failed to get database: %w", err)
+  }
+
+  properties, err := n.databaseProperties(ctx, database.Properties, title, frontMatter)
+  if err != nil {
+    return nil, err
+  }
+
+  page, err := n.Client.Page.Create(ctx, &notionapi.PageCreateRequest{
+    Parent: notionapi.Parent{
+      Type:       notionapi.ParentTypeDatabaseID,
+      DatabaseID: notionapi.DatabaseID(databaseID),
+    },
+    Properties: properties,
+    Icon:       frontMatter.Icon(),
+    Cover:      frontMatter.Cover(),
+  })
+  if err != nil {
+    return nil, fmt.Errorf("failed to create page: %w", err)
+  }
+
+  if err := n.InsertBlocks(ctx, page.ID.String(), blocks); err != nil {
+    return nil, fmt.Errorf("failed to insert blocks: %w", err)
+  }
+
+  return page, nil
+}
+
+// databaseProperties ... Map front matter keys to the database properties of the same name, typed by the database schema.
+// The title property takes the title, and keys without a property or with a property of an unsupported type are ignored.
+func (n *Notion) databaseProperties(ctx context.Context, schema notionapi.PropertyConfigs, title string, frontMatter converter.FrontMatter) (notionapi.Properties, error) {
+  properties := notionapi.Properties{}
+  var usersByEmail map[string]notionapi.User
+
+  for name, config := range schema {
+    if config.GetType() == notionapi.PropertyConfigTypeTitle {
+      properties[name] = &notionapi.TitleProperty{
+        Type:  notionapi.PropertyTypeTitle,
+        Title: chunk.RichText(title, nil),
+      }
+      continue
+    }
+
+    value, ok := frontMatterValue(frontMatter, name)
+    if !ok || value == nil {
+      continue
+    }
+
+    if config.GetType() == notionapi.PropertyConfigTypePeople && usersByEmail == nil {
+      users, err := n.usersByEmail(ctx)
+      if err != nil {
+        return nil, fmt.Errorf("failed to list users: %w", err)
+      }
+      usersByEmail = users
+    }
+
+    property, err := databasePropertyValue(config.GetType(), value, usersByEmail)
+    if errors.Is(err, errUnsupportedProperty) {
+      log.Printf("skipped front matter %q: %s properties are not supported", name, config.GetType())
+      continue
+    }
+    if err != nil {
+      return nil, fmt.Errorf("front matter %q does not fit %s property %q: %w", name, config.GetType(), name, err)
+    }
+    properties[name] = property
+  }
+
+  return properties, nil
+}
+
+// frontMatterValue ... Value of the front matter key matching a property name, ignoring case
+func frontMatterValue(frontMatter converter.FrontMatter, name string) (any, bool) {
+  if value, ok := frontMatter[name]; ok {
+    return value, true
+  }
+  for key, value := range frontMatter {
+    if strings.EqualFold(key, name) {
+      return value, true
+    }
+  }
+  return nil, false
+}
+
+// databasePropertyValue ... Convert a front matter value to a property value of the given type
+func databasePropertyValue(propertyType notionapi.PropertyConfigType, value any, usersByEmail map[string]notionapi.User) (notionapi.Property, error) {
+  switch propertyType {
+  case notionapi.PropertyConfigTypeRichText:
+    text, err := scalarString(value)
+    if err != nil {
+      return nil, err
+    }
+    return &notionapi.RichTextProperty{Type: notionapi.PropertyTypeRichText, RichText: chunk.RichText(text, nil)}, nil
+
+  case notionapi.PropertyConfigTypeSelect:
+    name, err := scalarString(value)
+    if err != nil {
+      return nil, err
+    }
+    return &notionapi.SelectProperty{Type: notionapi.PropertyTypeSelect, Select: notionapi.Option{Name: name}}, nil
+
+  case notionapi.PropertyConfigStatus:
+    name, err := scalarString(value)
+    if err != nil {
+      return nil, err
+    }
+    return &notionapi.StatusProperty{Type: notionapi.PropertyTypeStatus, Status: notionapi.Status{Name: name}}, nil
+
+  case notionapi.PropertyConfigTypeMultiSelect:
+    names, err := stringList(value)
+    if err != nil {
+      return nil, err
+    }
+    options := make([]notionapi.Option, len(names))
+    for i, name := range names {
+      options[i] = notionapi.Option{Name: name}
+    }
+    return &notionapi.MultiSelectProperty{Type: notionapi.PropertyTypeMultiSelect, MultiSelect: options}, nil
+
+  case notionapi.PropertyConfigTypeDate:
+    start, err := dateString(value)
+    if err != nil {
+      return nil, err
+    }
+    return &dateProperty{Type: notionapi.PropertyTypeDate, Date: dateValue{Start: start}}, nil
+
+  case notionapi.PropertyConfigTypeCheckbox:
+    checked, ok := value.(bool)
+    if !ok {
+      return nil, fmt.Errorf("expected true or false, got %v", value)
+    }
+    return &notionapi.CheckboxProperty{Type: notionapi.PropertyTypeCheckbox, Checkbox: checked}, nil
+
+  case notionapi.PropertyConfigTypeNumber:
+    var number float64
+    switch v := value.(type) {
+    case int:
+      number = float64(v)
+    case int64:
+      number = float64(v)
+    case uint64:
+      number = float64(v)
+    case float64:
+      number = v
+    default:
+      return nil, fmt.Errorf("expected a number, got %v", value)
+    }
+    return &notionapi.NumberProperty{Type: notionapi.PropertyTypeNumber, Number: number}, nil
+
+  case notionapi.PropertyConfigTypeURL:
+    url, err := scalarString(value)
+    if err != nil {
+      return nil, err
+    }
+    return &notionapi.URLProperty{Type: notionapi.PropertyTypeURL, URL: url}, nil
+
+  case notionapi.PropertyConfigTypePeople:
+    emails, err := stringList(value)
+    if err != nil {
+      return nil, err
+    }
+    people := make([]notionapi.User, len(emails))
+    for i, email := range emails {
+      user, ok := usersByEmail[strings.ToLower(email)]
+      if !ok {
+        return nil, fmt.Errorf("no user with email %s", email)
+      }
+      people[i] = notionapi.User{Object: notionapi.ObjectTypeUser, ID: user.ID}
+    }
+    return &notionapi.PeopleProperty{Type: notionapi.PropertyTypePeople, People: people}, nil
+  }
+
+  return nil, fmt.Errorf("%s: %w", propertyType, errUnsupportedProperty)
+}
+
+// scalarString ... Front matter value as a string, failing for lists and maps
+func scalarString(value any) (string, error) {
+  switch v := value.(type) {
+  case string:
+    return v, nil
+  case bool, int, int64, uint64, float64:
+    return fmt.Sprint(v), nil
+  }
+  return "", fmt.Errorf("expected a single value, got %v", value)
+}
+
+// stringList ... Front matter value as a list of strings, treating a single value as a list of one
+func stringList(value any) ([]string, error) {
+  values, ok := value.([]any)
+  if !ok {
+    values = []any{value}
+  }
+
+  list := make([]string, len(values))
+  for i, v := range values {
+    s, err := scalarString(v)
+    if err != nil {
+      return nil, fmt.Errorf("expected a list of values, got %v", value)
+    }
+    list[i] = s
+  }
+  return list, nil
+}
+
+// dateString ... Front matter value as an ISO 8601 date, or a date-time when it has a time
+func dateString(value any) (string, error) {
+  switch v := value.(type) {
+  // yaml.v3 decodes unquoted timestamps like 2024-01-02 into time.Time, and quoted ones stay strings
+  case time.Time:
+    if v.Equal(v.Truncate(24*time.Hour)) && v.Location() == time.UTC {
+      return v.Format(time.DateOnly), nil
+    }
+    return v.Format(time.RFC3339), nil
+  case string:
+    if _, err := time.Parse(time.DateOnly, v); err == nil {
+      return v, nil
+    }
+    if _, err := time.Parse(time.RFC3339, v); err == nil {
+      return v, nil
+    }
+  }
+  return "", fmt.Errorf("expected a date like 2006-01-02 or 2006-01-02T15:04:05Z07:00, got %v", value)
+}
+
+// usersByEmail ... Map the emails of the people in the workspace to their users
+func (n *Notion) usersByEmail(ctx context.Context) (map[string]notionapi.User, error) {
+  users := map[string]notionapi.User{}
+  startCursor := notionapi.Cursor("")
+
+  for {
+    response, err := n.Client.User.List(ctx, &notionapi.Pagination{
+      StartCursor: startCursor,
+      PageSize:    100,
+    })
+    if err != nil {
+      return nil, err
+    }
+
+    for _, user := range response.Results {
+      if user.Person != nil && user.Person.Email != "" {
+        users[strings.ToLower(user.Person.Email)] = user
+      }
+    }
+
+    if !response.HasMore {
+      return users, nil
+    }
+    startCursor = response.NextCursor
+  }
+}
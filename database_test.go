@@ -0,0 +1,152 @@
+package main
+
+import (
+  "encoding/json"
+  "io"
+  "net/http"
+  "os"
+  "path/filepath"
+  "testing"
+
+  "github.com/jomei/notionapi"
+  "github.com/sioncojp/go-markdown-to-notion/converter"
+  "github.com/stretchr/testify/assert"
+  "github.com/stretchr/testify/require"
+)
+
+// parseFrontMatter ... Front matter as ConvertDocument parses it from the top of a markdown file
+func parseFrontMatter(t *testing.T, frontMatter string) converter.FrontMatter {
+  t.Helper()
+
+  path := filepath.Join(t.TempDir(), "test.md")
+  require.NoError(t, os.WriteFile(path, []byte("---\n"+frontMatter+"---\n"), 0o644))
+  document, err := converter.ConvertDocument(&converter.Converter{MarkdownFilePath: path})
+  require.NoError(t, err)
+  return document.FrontMatter
+}
+
+func TestDatabasePropertyValue(t *testing.T) {
+  t.Run("converts values by property type", func(t *testing.T) {
+    users := map[string]notionapi.User{"alice@example.com": {ID: "alice-id"}}
+
+    tests := []struct {
+      propertyType notionapi.PropertyConfigType
+      value        any
+      expected     notionapi.Property
+    }{
+      {notionapi.PropertyConfigTypeSelect, "Draft", &notionapi.SelectProperty{Type: notionapi.PropertyTypeSelect, Select: notionapi.Option{Name: "Draft"}}},
+      {notionapi.PropertyConfigStatus, "In progress", &notionapi.StatusProperty{Type: notionapi.PropertyTypeStatus, Status: notionapi.Status{Name: "In progress"}}},
+      {notionapi.PropertyConfigTypeMultiSelect, []any{"go", "notion"}, &notionapi.MultiSelectProperty{Type: notionapi.PropertyTypeMultiSelect, MultiSelect: []notionapi.Option{{Name: "go"}, {Name: "notion"}}}},
+      {notionapi.PropertyConfigTypeMultiSelect, "go", &notionapi.MultiSelectProperty{Type: notionapi.PropertyTypeMultiSelect, MultiSelect: []notionapi.Option{{Name: "go"}}}},
+      {notionapi.PropertyConfigTypeDate, parseFrontMatter(t, "due: 2024-01-02\n")["due"], &dateProperty{Type: notionapi.PropertyTypeDate, Date: dateValue{Start: "2024-01-02"}}},
+      {notionapi.PropertyConfigTypeDate, parseFrontMatter(t, "due: 2024-01-02T10:30:00Z\n")["due"], &dateProperty{Type: notionapi.PropertyTypeDate, Date: dateValue{Start: "2024-01-02T10:30:00Z"}}},
+      {notionapi.PropertyConfigTypeDate, parseFrontMatter(t, "due: 2024-01-02T10:30:00+09:00\n")["due"], &dateProperty{Type: notionapi.PropertyTypeDate, Date: dateValue{Start: "2024-01-02T10:30:00+09:00"}}},
+      {notionapi.PropertyConfigTypeDate, parseFrontMatter(t, "due: \"2024-01-02\"\n")["due"], &dateProperty{Type: notionapi.PropertyTypeDate, Date: dateValue{Start: "2024-01-02"}}},
+      {notionapi.PropertyConfigTypeCheckbox, true, &notionapi.CheckboxProperty{Type: notionapi.PropertyTypeCheckbox, Checkbox: true}},
+      {notionapi.PropertyConfigTypeNumber, 3, &notionapi.NumberProperty{Type: notionapi.PropertyTypeNumber, Number: 3}},
+      {notionapi.PropertyConfigTypeNumber, 1.5, &notionapi.NumberProperty{Type: notionapi.PropertyTypeNumber, Number: 1.5}},
+      {notionapi.PropertyConfigTypeNumber, int64(3), &notionapi.NumberProperty{Type: notionapi.PropertyTypeNumber, Number: 3}},
+      {notionapi.PropertyConfigTypeNumber, uint64(18446744073709551615), &notionapi.NumberProperty{Type: notionapi.PropertyTypeNumber, Number: 18446744073709551615}},
+      {notionapi.PropertyConfigTypeURL, "https://example.com", &notionapi.URLProperty{Type: notionapi.PropertyTypeURL, URL: "https://example.com"}},
+      {notionapi.PropertyConfigTypePeople, "Alice@example.com", &notionapi.PeopleProperty{Type: notionapi.PropertyTypePeople, People: []notionapi.User{{Object: notionapi.ObjectTypeUser, ID: "alice-id"}}}},
+    }
+
+    for _, tt := range tests {
+      property, err := databasePropertyValue(tt.propertyType, tt.value, users)
+      require.NoError(t, err, tt.propertyType)
+      assert.Equal(t, tt.expected, property, tt.propertyType)
+    }
+  })
+
+  t.Run("returns error for mismatched types", func(t *testing.T) {
+    tests := []struct {
+      propertyType notionapi.PropertyConfigType
+      value        any
+    }{
+      {notionapi.PropertyConfigTypeSelect, []any{"a", "b"}},
+      {notionapi.PropertyConfigTypeMultiSelect, []any{map[string]any{"a": 1}}},
+      {notionapi.PropertyConfigTypeDate, "tomorrow"},
+      {notionapi.PropertyConfigTypeCheckbox, "yes"},
+      {notionapi.PropertyConfigTypeNumber, "three"},
+      {notionapi.PropertyConfigTypePeople, "bob@example.com"},
+    }
+
+    for _, tt := range tests {
+      _, err := databasePropertyValue(tt.propertyType, tt.value, nil)
+      assert.Error(t, err, tt.propertyType)
+      assert.NotErrorIs(t, err, errUnsupportedProperty, tt.propertyType)
+    }
+  })
+
+  t.Run("returns unsupported error for other property types", func(t *testing.T) {
+    for _, propertyType := range []notionapi.PropertyConfigType{
+      notionapi.PropertyConfigTypeFormula,
+      notionapi.PropertyConfigTypeRelation,
+      notionapi.PropertyConfigTypeEmail,
+      notionapi.PropertyConfigTypePhoneNumber,
+    } {
+      _, err := databasePropertyValue(propertyType, "x", nil)
+      assert.ErrorIs(t, err, errUnsupportedProperty, propertyType)
+    }
+  })
+}
+
+func TestPublishToDatabase(t *testing.T) {
+  t.Run("creates a page with front matter properties", func(t *testing.T) {
+    var created map[string]any
+    var appended bool
+    mux := http.NewServeMux()
+    mux.HandleFunc("GET /v1/databases/database-id", func(w http.ResponseWriter, r *http.Request) {
+      _, _ = io.WriteString(w, `{"object":"database","id":"database-id","properties":{
+        "Name":{"id":"title","type":"title","title":{}},
+        "Status":{"id":"s","type":"select","select":{"options":[]}},
+        "Owner":{"id":"o","type":"people","people":{}},
+        "Due":{"id":"d","type":"date","date":{}},
+        "Email":{"id":"e","type":"email","email":{}}
+      }}`)
+    })
+    mux.HandleFunc("GET /v1/users", func(w http.ResponseWriter, r *http.Request) {
+      _, _ = io.WriteString(w, `{"object":"list","results":[{"object":"user","id":"alice-id","type":"person","person":{"email":"alice@example.com"}}],"has_more":false}`)
+    })
+    mux.HandleFunc("POST /v1/pages", func(w http.ResponseWriter, r *http.Request) {
+      require.NoError(t, json.NewDecoder(r.Body).Decode(&created))
+      _, _ = io.WriteString(w, `{"object":"page","id":"page-id","url":"https://www.notion.so/page-id"}`)
+    })
+    mux.HandleFunc("PATCH /v1/blocks/page-id/children", func(w http.ResponseWriter, r *http.Request) {
+      appended = true
+      _, _ = io.WriteString(w, `{"object":"list","results":[]}`)
+    })
+    notion := newTestNotion(t, mux)
+
+    frontMatter := parseFrontMatter(t, "status: Draft\nowner: alice@example.com\ndue: 2024-01-02\nemail: skipped@example.com\ntags: [ignored]\nicon: 📝\n")
+    page, err := notion.PublishToDatabase(t.Context(), "database-id", "RFC 1", frontMatter, []notionapi.Block{newTableOfContentsBlock()})
+
+    require.NoError(t, err)
+    assert.Equal(t, "https://www.notion.so/page-id", page.URL)
+    assert.True(t, appended)
+    assert.Equal(t, "database-id", created["parent"].(map[string]any)["database_id"])
+    assert.Equal(t, "📝", created["icon"].(map[string]any)["emoji"])
+
+    properties := created["properties"].(map[string]any)
+    assert.Len(t, properties, 4)
+    assert.Equal(t, "RFC 1", properties["Name"].(map[string]any)["title"].([]any)[0].(map[string]any)["text"].(map[string]any)["content"])
+    assert.Equal(t, "Draft", properties["Status"].(map[string]any)["select"].(map[string]any)["name"])
+    assert.Equal(t, "alice-id", properties["Owner"].(map[string]any)["people"].([]any)[0].(map[string]any)["id"])
+    assert.Equal(t, "2024-01-02", properties["Due"].(map[string]any)["date"].(map[string]any)["start"])
+  })
+
+  t.Run("returns error naming the mismatched property", func(t *testing.T) {
+    mux := http.NewServeMux()
+    mux.HandleFunc("GET /v1/databases/database-id", func(w http.ResponseWriter, r *http.Request) {
+      _, _ = io.WriteString(w, `{"object":"database","id":"database-id","properties":{
+        "Name":{"id":"title","type":"title","title":{}},
+        "Done":{"id":"c","type":"checkbox","checkbox":{}}
+      }}`)
+    })
+    notion := newTestNotion(t, mux)
+
+    _, err := notion.PublishToDatabase(t.Context(), "database-id", "RFC 1", converter.FrontMatter{"Done": "yes"}, nil)
+
+    assert.ErrorContains(t, err, `front matter "Done" does not fit checkbox property "Done"`)
+  })
+}
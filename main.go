@@ -6,8 +6,13 @@ import (
   "log"
   "os"
   "os/signal"
+  "path/filepath"
+  "slices"
+  "strings"
   "syscall"
 
+  "github.com/jomei/notionapi"
+  "github.com/sioncojp/go-markdown-to-notion/chunk"
   "github.com/sioncojp/go-markdown-to-notion/converter"
   "github.com/urfave/cli/v3"
 )
@@ -56,55 +61,55 @@ func run() error {
       {
         Name:  "upload",
         Usage: "upload markdown to notion",
-        Flags: []cli.Flag{
-          &cli.StringFlag{
-            Name:     "notion-page-or-block-id",
-            Usage:    "output notion page or below this notion block id",
-            Required: true,
-          },
-          &cli.StringFlag{
-            Name:     "source-md-filepath",
-            Usage:    "source markdown file path",
-            Required: true,
-          },
-          &cli.StringFlag{
-            Name:  "h1-color",
-            Usage: "h1 color",
-            Value: "blue",
-          },
-          &cli.StringFlag{
-            Name:  "h2-color",
-            Usage: "h2 color",
-            Value: "orange",
-          },
-          &cli.StringFlag{
-            Name:  "h3-color",
-            Usage: "h3 color",
-            Value: "yellow",
+        Flags: slices.Concat(
+          []cli.Flag{
+            &cli.StringFlag{
+              Name:     "notion-page-or-block-id",
+              Usage:    "output notion page or below this notion block id",
+              Required: true,
+            },
           },
-          &cli.BoolFlag{
-            Name:  "is-add-table-of-contents",
-            Usage: "add table of contents",
-            Value: false,
+          converterFlags(),
+          []cli.Flag{
+            &cli.BoolFlag{
+              Name:  "is-add-table-of-contents",
+              Usage: "add table of contents",
+              Value: false,
+            },
+            &cli.BoolFlag{
+              Name:  "create-page",
+              Usage: "create a new child page under notion-page-or-block-id and upload into it",
+              Value: false,
+            },
+            &cli.StringFlag{
+              Name:  "title",
+              Usage: "title of the page created with create-page (default: front matter title, first h1 or file name)",
+            },
           },
-        },
+        ),
         Action: func(ctx context.Context, cmd *cli.Command) error {
           NotionPageOrBlockID = cmd.String("notion-page-or-block-id")
-          SourceMdFilePath = cmd.String("source-md-filepath")
-          H1Color = cmd.String("h1-color")
-          H2Color = cmd.String("h2-color")
-          H3Color = cmd.String("h3-color")
-
-          c := &converter.Converter{
-            MarkdownFilePath: SourceMdFilePath,
-            H1Color:          H1Color,
-            H2Color:          H2Color,
-            H3Color:          H3Color,
+
+          c, err := newConverter(ctx, cmd, notion)
+          if err != nil {
+            return err
           }
 
-          blocks, err := converter.Convert(c)
+          document, err := convert(c)
           if err != nil {
-            return fmt.Errorf("failed to convert markdown to notion: %w", err)
+            return err
+          }
+          blocks := document.Blocks
+          frontMatter := document.FrontMatter
+
+          // Upload into a new child page instead of appending to the given one
+          if cmd.Bool("create-page") {
+            page, err := notion.CreatePage(ctx, NotionPageOrBlockID, documentTitle(document, cmd.String("title"), SourceMdFilePath), frontMatter.Icon(), frontMatter.Cover())
+            if err != nil {
+              return fmt.Errorf("failed to create page: %w", err)
+            }
+            NotionPageOrBlockID = page.ID.String()
+            fmt.Printf("%s %s\n", page.ID, page.URL)
           }
 
           if cmd.Bool("is-add-table-of-contents") {
@@ -117,6 +122,62 @@ func run() error {
             return fmt.Errorf("failed to insert blocks: %w", err)
           }
 
+          // Front matter updates the page the blocks are uploaded to
+          if cmd.Bool("create-page") {
+            return nil
+          }
+          if title, icon, cover := frontMatter.Title(), frontMatter.Icon(), frontMatter.Cover(); title != "" || icon != nil || cover != nil {
+            if err := notion.UpdatePage(ctx, NotionPageOrBlockID, title, icon, cover); err != nil {
+              return fmt.Errorf("failed to update page from front matter: %w", err)
+            }
+          }
+
+          return nil
+        },
+      },
+      // subcommand: publish-to-database
+      {
+        Name:  "publish-to-database",
+        Usage: "publish markdown as a new page in a notion database, with front matter as page properties",
+        Flags: slices.Concat(
+          []cli.Flag{
+            &cli.StringFlag{
+              Name:     "notion-database-id",
+              Usage:    "create the page in this notion database id",
+              Required: true,
+            },
+          },
+          converterFlags(),
+          []cli.Flag{
+            &cli.BoolFlag{
+              Name:  "is-add-table-of-contents",
+              Usage: "add table of contents",
+              Value: false,
+            },
+          },
+        ),
+        Action: func(ctx context.Context, cmd *cli.Command) error {
+          c, err := newConverter(ctx, cmd, notion)
+          if err != nil {
+            return err
+          }
+
+          document, err := convert(c)
+          if err != nil {
+            return err
+          }
+          blocks := document.Blocks
+
+          if cmd.Bool("is-add-table-of-contents") {
+            blocks = append([]notionapi.Block{newTableOfContentsBlock()}, blocks...)
+          }
+
+          page, err := notion.PublishToDatabase(ctx, cmd.String("notion-database-id"), documentTitle(document, "", SourceMdFilePath), document.FrontMatter, blocks)
+          if err != nil {
+            return fmt.Errorf("failed to publish to database: %w", err)
+          }
+
+          fmt.Printf("%s %s\n", page.ID, page.URL)
           return nil
         },
       },
@@ -150,3 +211,131 @@ func run() error {
 
   return nil
 }
+
+// documentTitle ... Title of the page created for a document: the given title,
+// or else the front matter title, the first h1 or the file name
+func documentTitle(document *converter.Document, title string, markdownFilePath string) string {
+  for _, candidate := range []string{title, document.FrontMatter.Title(), document.Heading} {
+    if candidate != "" {
+      return candidate
+    }
+  }
+  return strings.TrimSuffix(filepath.Base(markdownFilePath), filepath.Ext(markdownFilePath))
+}
+
+// convert ... Convert the markdown file and split the blocks over the rich text limit of Notion
+func convert(c *converter.Converter) (*converter.Document, error) {
+  document, err := converter.ConvertDocument(c)
+  if err != nil {
+    return nil, fmt.Errorf("failed to convert markdown to notion: %w", err)
+  }
+
+  blocks, count := chunk.SplitBlocks(document.Blocks)
+  if count > 0 {
+    log.Printf("split %d blocks with more than %d rich text elements into consecutive blocks", count, chunk.RichTextLimit)
+  }
+  document.Blocks = blocks
+
+  return document, nil
+}
+
+// converterFlags ... Flags of the commands converting a markdown file
+func converterFlags() []cli.Flag {
+  return []cli.Flag{
+    &cli.StringFlag{
+      Name:     "source-md-filepath",
+      Usage:    "source markdown file path",
+      Required: true,
+    },
+    &cli.StringFlag{
+      Name:  "h1-color",
+      Usage: "h1 color",
+      Value: "blue",
+    },
+    &cli.StringFlag{
+      Name:  "h2-color",
+      Usage: "h2 color",
+      Value: "orange",
+    },
+    &cli.StringFlag{
+      Name:  "h3-color",
+      Usage: "h3 color",
+      Value: "yellow",
+    },
+    &cli.StringFlag{
+      Name:  "deep-heading-style",
+      Usage: "how to convert h4-h6 headings: heading_3, paragraph or toggle",
+      Value: converter.DeepHeadingHeading3,
+    },
+    &cli.StringSliceFlag{
+      Name:  "alert-style",
+      Usage: "callout style of a GitHub alert kind as KIND=EMOJI:COLOR (e.g. NOTE=📝:gray_background)",
+    },
+    &cli.StringFlag{
+      Name:  "html-block-policy",
+      Usage: "how to convert raw html blocks: drop, code or parse",
+      Value: converter.HTMLBlockDrop,
+    },
+    &cli.StringFlag{
+      Name:  "language-alias-file",
+      Usage: "YAML or JSON file mapping code fence languages to notion languages (e.g. tpl: html)",
+    },
+    &cli.StringSliceFlag{
+      Name:  "language-alias",
+      Usage: "notion language of a code fence language as ALIAS=LANGUAGE (e.g. tpl=html)",
+    },
+    &cli.BoolFlag{
+      Name:  "soft-line-break-as-newline",
+      Usage: "keep soft line breaks in paragraphs as newlines instead of spaces",
+      Value: false,
+    },
+  }
+}
+
+// newConverter ... Create a converter from the converter flags, uploading images through notion
+func newConverter(ctx context.Context, cmd *cli.Command, notion *Notion) (*converter.Converter, error) {
+  SourceMdFilePath = cmd.String("source-md-filepath")
+  H1Color = cmd.String("h1-color")
+  H2Color = cmd.String("h2-color")
+  H3Color = cmd.String("h3-color")
+
+  alertStyles := map[string]converter.AlertStyle{}
+  for _, option := range cmd.StringSlice("alert-style") {
+    kind, style, err := converter.ParseAlertStyle(option)
+    if err != nil {
+      return nil, err
+    }
+    alertStyles[kind] = style
+  }
+
+  languageAliases := map[string]string{}
+  if path := cmd.String("language-alias-file"); path != "" {
+    aliases, err := converter.LoadLanguageAliases(path)
+    if err != nil {
+      return nil, err
+    }
+    languageAliases = aliases
+  }
+  for _, option := range cmd.StringSlice("language-alias") {
+    alias, language, err := converter.ParseLanguageAlias(option)
+    if err != nil {
+      return nil, err
+    }
+    languageAliases[alias] = language
+  }
+
+  return &converter.Converter{
+    MarkdownFilePath:       SourceMdFilePath,
+    H1Color:                H1Color,
+    H2Color:                H2Color,
+    H3Color:                H3Color,
+    DeepHeadingStyle:       cmd.String("deep-heading-style"),
+    AlertStyles:            alertStyles,
+    HTMLBlockPolicy:        cmd.String("html-block-policy"),
+    LanguageAliases:        languageAliases,
+    SoftLineBreakAsNewline: cmd.Bool("soft-line-break-as-newline"),
+    ImageUploader: func(filename, contentType string, data []byte) (string, error) {
+      return notion.UploadFile(ctx, filename, contentType, data)
+    },
+  }, nil
+}
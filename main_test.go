@@ -0,0 +1,20 @@
+package main
+
+import (
+  "testing"
+
+  "github.com/sioncojp/go-markdown-to-notion/converter"
+  "github.com/stretchr/testify/assert"
+)
+
+func TestDocumentTitle(t *testing.T) {
+  document := &converter.Document{
+    FrontMatter: converter.FrontMatter{"title": "Front Matter"},
+    Heading:     "Heading",
+  }
+
+  assert.Equal(t, "Flag", documentTitle(document, "Flag", "docs/design.md"))
+  assert.Equal(t, "Front Matter", documentTitle(document, "", "docs/design.md"))
+  assert.Equal(t, "Heading", documentTitle(&converter.Document{Heading: "Heading"}, "", "docs/design.md"))
+  assert.Equal(t, "design", documentTitle(&converter.Document{}, "", "docs/design.md"))
+}
@@ -1,20 +1,43 @@
 package main
 
 import (
+  "bytes"
   "context"
+  "encoding/json"
+  "fmt"
+  "io"
+  "log"
+  "mime/multipart"
+  "net/http"
+  "net/textproto"
 
   "github.com/jomei/notionapi"
+  "github.com/sioncojp/go-markdown-to-notion/chunk"
+)
+
+const (
+  // NotionAPIBaseURL ... base URL of the Notion API
+  NotionAPIBaseURL = "https://api.notion.com/v1"
+
+  // NotionVersion ... Notion API version sent with requests made outside notionapi
+  NotionVersion = "2022-06-28"
 )
 
 // Notion ... Store Notion client
 type Notion struct {
   Client *notionapi.Client
+
+  // HTTPClient and APIBaseURL are used for endpoints notionapi does not cover, such as file uploads.
+  HTTPClient *http.Client
+  APIBaseURL string
 }
 
 // NewNotionClient ... Create a new Notion client
 func NewNotionClient() *Notion {
   client := &Notion{
-    Client: notionapi.NewClient(notionapi.Token(NotionAPIToken)),
+    Client:     notionapi.NewClient(notionapi.Token(NotionAPIToken)),
+    HTTPClient: http.DefaultClient,
+    APIBaseURL: NotionAPIBaseURL,
   }
   return client
 }
@@ -55,22 +78,37 @@ func (n *Notion) DeleteAllBlocks(ctx context.Context, pageOrBlockID string) erro
 }
 
 // InsertBlocks ... Insert blocks into a Notion page
+// Children nested deeper than one request accepts are appended to the created blocks afterwards.
 func (n *Notion) InsertBlocks(ctx context.Context, blockID string, blocks []notionapi.Block) error {
-  // Notion API has a limit of 100 blocks per request
-  const blockChildrenSize = 100
-
-  for i := 0; i < len(blocks); i += blockChildrenSize {
-    end := i + blockChildrenSize
-    if end > len(blocks) {
-      end = len(blocks)
-    }
+  parents := make([]notionapi.Block, len(blocks))
+  deferred := make([][]notionapi.Block, len(blocks))
+  for i, block := range blocks {
+    parents[i], deferred[i] = chunk.DeferChildren(block)
+  }
 
-    if _, err := n.Client.Block.AppendChildren(ctx, notionapi.BlockID(blockID), &notionapi.AppendBlockChildrenRequest{
-      Children: blocks[i:end],
-    }); err != nil {
+  // Notion API limits the number, nested descendants and size of blocks per request
+  offset := 0
+  for _, batch := range chunk.Blocks(parents) {
+    res, err := n.Client.Block.AppendChildren(ctx, notionapi.BlockID(blockID), &notionapi.AppendBlockChildrenRequest{
+      Children: batch,
+    })
+    if err != nil {
       return err
     }
 
+    for i := range batch {
+      children := deferred[offset+i]
+      if len(children) == 0 {
+        continue
+      }
+      if i >= len(res.Results) {
+        return fmt.Errorf("append response has no block %d to insert its children into", offset+i)
+      }
+      if err := n.InsertBlocks(ctx, res.Results[i].GetID().String(), children); err != nil {
+        return err
+      }
+    }
+    offset += len(batch)
   }
 
   return nil
@@ -78,7 +116,18 @@ func (n *Notion) InsertBlocks(ctx context.Context, blockID string, blocks []noti
 
 // InsertTableOfContents ... Insert a Table of Contents block into a Notion page
 func (n *Notion) InsertTableOfContents(ctx context.Context, blockID string) error {
-  block := &notionapi.TableOfContentsBlock{
+  if _, err := n.Client.Block.AppendChildren(ctx, notionapi.BlockID(blockID), &notionapi.AppendBlockChildrenRequest{
+    Children: []notionapi.Block{newTableOfContentsBlock()},
+  }); err != nil {
+    return err
+  }
+
+  return nil
+}
+
+// newTableOfContentsBlock ... Create a Table of Contents block
+func newTableOfContentsBlock() *notionapi.TableOfContentsBlock {
+  return &notionapi.TableOfContentsBlock{
     BasicBlock: notionapi.BasicBlock{
       Object: notionapi.ObjectTypeBlock,
       Type:   notionapi.BlockTypeTableOfContents,
@@ -87,12 +136,154 @@ func (n *Notion) InsertTableOfContents(ctx context.Context, blockID string) erro
       Color: "default",
     },
   }
+}
 
-  if _, err := n.Client.Block.AppendChildren(ctx, notionapi.BlockID(blockID), &notionapi.AppendBlockChildrenRequest{
-    Children: []notionapi.Block{block},
-  }); err != nil {
+// UpdatePage ... Update the title, icon and cover of a Notion page, leaving empty ones unchanged
+// A block that is not a page has none of them, so it is skipped with a log message.
+func (n *Notion) UpdatePage(ctx context.Context, pageID string, title string, icon *notionapi.Icon, cover *notionapi.Image) error {
+  block, err := n.Client.Block.Get(ctx, notionapi.BlockID(pageID))
+  if err != nil {
+    return err
+  }
+  if block.GetType() != notionapi.BlockTypeChildPage {
+    log.Printf("skipped updating the page properties of %s: it is a %s block, not a page", pageID, block.GetType())
+    return nil
+  }
+
+  request := &notionapi.PageUpdateRequest{
+    Icon:  icon,
+    Cover: cover,
+  }
+
+  if title != "" {
+    // Pages in a database name their title property after the database column
+    page, err := n.Client.Page.Get(ctx, notionapi.PageID(pageID))
+    if err != nil {
+      return err
+    }
+
+    request.Properties = notionapi.Properties{
+      titlePropertyName(page): &notionapi.TitleProperty{
+        Type:  notionapi.PropertyTypeTitle,
+        Title: chunk.RichText(title, nil),
+      },
+    }
+  }
+
+  if _, err := n.Client.Page.Update(ctx, notionapi.PageID(pageID), request); err != nil {
     return err
   }
 
   return nil
 }
+
+// CreatePage ... Create a child page under a Notion page
+func (n *Notion) CreatePage(ctx context.Context, parentPageID string, title string, icon *notionapi.Icon, cover *notionapi.Image) (*notionapi.Page, error) {
+  page, err := n.Client.Page.Create(ctx, &notionapi.PageCreateRequest{
+    Parent: notionapi.Parent{
+      Type:   notionapi.ParentTypePageID,
+      PageID: notionapi.PageID(parentPageID),
+    },
+    Properties: notionapi.Properties{
+      "title": &notionapi.TitleProperty{
+        Type:  notionapi.PropertyTypeTitle,
+        Title: chunk.RichText(title, nil),
+      },
+    },
+    Icon:  icon,
+    Cover: cover,
+  })
+  if err != nil {
+    return nil, err
+  }
+
+  return page, nil
+}
+
+// titlePropertyName ... Name of the title property of a Notion page
+func titlePropertyName(page *notionapi.Page) string {
+  for name, property := range page.Properties {
+    if property.GetType() == notionapi.PropertyTypeTitle {
+      return name
+    }
+  }
+  return "title"
+}
+
+// fileUploadResponse ... Response of the Notion file upload endpoints
+type fileUploadResponse struct {
+  ID     string `json:"id"`
+  Status string `json:"status"`
+}
+
+// UploadFile ... Upload a file through the Notion file upload API and return the file upload ID
+func (n *Notion) UploadFile(ctx context.Context, filename, contentType string, data []byte) (string, error) {
+  // Create the file upload
+  payload, err := json.Marshal(map[string]string{
+    "mode":         "single_part",
+    "filename":     filename,
+    "content_type": contentType,
+  })
+  if err != nil {
+    return "", err
+  }
+
+  var created fileUploadResponse
+  if err := n.request(ctx, http.MethodPost, "/file_uploads", "application/json", bytes.NewReader(payload), &created); err != nil {
+    return "", fmt.Errorf("failed to create file upload: %w", err)
+  }
+
+  // Send the file contents
+  body := &bytes.Buffer{}
+  writer := multipart.NewWriter(body)
+  header := textproto.MIMEHeader{}
+  header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file"; filename=%q`, filename))
+  header.Set("Content-Type", contentType)
+  part, err := writer.CreatePart(header)
+  if err != nil {
+    return "", err
+  }
+  if _, err := part.Write(data); err != nil {
+    return "", err
+  }
+  if err := writer.Close(); err != nil {
+    return "", err
+  }
+
+  var sent fileUploadResponse
+  if err := n.request(ctx, http.MethodPost, fmt.Sprintf("/file_uploads/%s/send", created.ID), writer.FormDataContentType(), body, &sent); err != nil {
+    return "", fmt.Errorf("failed to send file upload: %w", err)
+  }
+  if sent.Status != "uploaded" {
+    return "", fmt.Errorf("file upload %s has unexpected status %q", created.ID, sent.Status)
+  }
+
+  return created.ID, nil
+}
+
+// request ... Send a request to the Notion API and decode the JSON response into out
+func (n *Notion) request(ctx context.Context, method, path, contentType string, body io.Reader, out any) error {
+  req, err := http.NewRequestWithContext(ctx, method, n.APIBaseURL+path, body)
+  if err != nil {
+    return err
+  }
+  req.Header.Set("Authorization", "Bearer "+n.Client.Token.String())
+  req.Header.Set("Notion-Version", NotionVersion)
+  req.Header.Set("Content-Type", contentType)
+
+  res, err := n.HTTPClient.Do(req)
+  if err != nil {
+    return err
+  }
+  defer res.Body.Close()
+
+  if res.StatusCode != http.StatusOK {
+    var apiErr notionapi.Error
+    if err := json.NewDecoder(res.Body).Decode(&apiErr); err != nil || apiErr.Message == "" {
+      return fmt.Errorf("notion api returned %s", res.Status)
+    }
+    return &apiErr
+  }
+
+  return json.NewDecoder(res.Body).Decode(out)
+}
@@ -0,0 +1,290 @@
+package main
+
+import (
+  "encoding/json"
+  "fmt"
+  "io"
+  "net/http"
+  "net/http/httptest"
+  "net/url"
+  "slices"
+  "testing"
+
+  "github.com/jomei/notionapi"
+  "github.com/sioncojp/go-markdown-to-notion/chunk"
+  "github.com/stretchr/testify/assert"
+  "github.com/stretchr/testify/require"
+)
+
+// redirectTransport sends requests to the host of a local stand-in server.
+type redirectTransport struct {
+  target *url.URL
+  next   http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *redirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+  req = req.Clone(req.Context())
+  req.URL.Scheme = r.target.Scheme
+  req.URL.Host = r.target.Host
+  return r.next.RoundTrip(req)
+}
+
+// newTestNotion creates a Notion client that talks to a local stand-in server.
+func newTestNotion(t *testing.T, handler http.Handler) *Notion {
+  t.Helper()
+
+  server := httptest.NewServer(handler)
+  t.Cleanup(server.Close)
+
+  target, err := url.Parse(server.URL)
+  require.NoError(t, err)
+  client := &http.Client{Transport: &redirectTransport{target: target, next: server.Client().Transport}}
+
+  return &Notion{
+    Client:     notionapi.NewClient(notionapi.Token("secret"), notionapi.WithHTTPClient(client)),
+    HTTPClient: server.Client(),
+    APIBaseURL: server.URL + "/v1",
+  }
+}
+
+func TestUploadFile(t *testing.T) {
+  t.Run("creates and sends a file upload", func(t *testing.T) {
+    mux := http.NewServeMux()
+    mux.HandleFunc("POST /v1/file_uploads", func(w http.ResponseWriter, r *http.Request) {
+      assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+      assert.Equal(t, NotionVersion, r.Header.Get("Notion-Version"))
+
+      var body map[string]string
+      require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+      assert.Equal(t, "image1.png", body["filename"])
+      assert.Equal(t, "image/png", body["content_type"])
+
+      _, _ = io.WriteString(w, `{"id":"upload-id","status":"pending"}`)
+    })
+    mux.HandleFunc("POST /v1/file_uploads/upload-id/send", func(w http.ResponseWriter, r *http.Request) {
+      file, header, err := r.FormFile("file")
+      require.NoError(t, err)
+      data, err := io.ReadAll(file)
+      require.NoError(t, err)
+      assert.Equal(t, "image1.png", header.Filename)
+      assert.Equal(t, "image/png", header.Header.Get("Content-Type"))
+      assert.Equal(t, []byte("png bytes"), data)
+
+      _, _ = io.WriteString(w, `{"id":"upload-id","status":"uploaded"}`)
+    })
+    notion := newTestNotion(t, mux)
+
+    id, err := notion.UploadFile(t.Context(), "image1.png", "image/png", []byte("png bytes"))
+
+    require.NoError(t, err)
+    assert.Equal(t, "upload-id", id)
+  })
+
+  t.Run("returns notion api errors", func(t *testing.T) {
+    notion := newTestNotion(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+      w.WriteHeader(http.StatusBadRequest)
+      _, _ = io.WriteString(w, `{"object":"error","status":400,"code":"validation_error","message":"bad filename"}`)
+    }))
+
+    _, err := notion.UploadFile(t.Context(), "image1.png", "image/png", []byte("png bytes"))
+
+    assert.ErrorContains(t, err, "bad filename")
+  })
+}
+
+func TestUpdatePage(t *testing.T) {
+  t.Run("updates title, icon and cover", func(t *testing.T) {
+    var body map[string]any
+    mux := http.NewServeMux()
+    handlePageBlock(mux, "page-id")
+    mux.HandleFunc("GET /v1/pages/page-id", func(w http.ResponseWriter, r *http.Request) {
+      _, _ = io.WriteString(w, `{"object":"page","id":"page-id","properties":{"Name":{"id":"title","type":"title","title":[]}}}`)
+    })
+    mux.HandleFunc("PATCH /v1/pages/page-id", func(w http.ResponseWriter, r *http.Request) {
+      require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+      _, _ = io.WriteString(w, `{"object":"page","id":"page-id"}`)
+    })
+    notion := newTestNotion(t, mux)
+
+    emoji := notionapi.Emoji("📝")
+    err := notion.UpdatePage(t.Context(), "page-id", "Design", &notionapi.Icon{Type: "emoji", Emoji: &emoji}, &notionapi.Image{
+      Type:     "external",
+      External: &notionapi.FileObject{URL: "https://example.com/cover.png"},
+    })
+
+    require.NoError(t, err)
+    properties := body["properties"].(map[string]any)
+    assert.Equal(t, "Design", properties["Name"].(map[string]any)["title"].([]any)[0].(map[string]any)["text"].(map[string]any)["content"])
+    assert.Equal(t, "📝", body["icon"].(map[string]any)["emoji"])
+    assert.Equal(t, "https://example.com/cover.png", body["cover"].(map[string]any)["external"].(map[string]any)["url"])
+  })
+
+  t.Run("skips fetching the page without title", func(t *testing.T) {
+    var body map[string]any
+    mux := http.NewServeMux()
+    handlePageBlock(mux, "page-id")
+    mux.HandleFunc("PATCH /v1/pages/page-id", func(w http.ResponseWriter, r *http.Request) {
+      require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+      _, _ = io.WriteString(w, `{"object":"page","id":"page-id"}`)
+    })
+    notion := newTestNotion(t, mux)
+
+    emoji := notionapi.Emoji("📝")
+    err := notion.UpdatePage(t.Context(), "page-id", "", &notionapi.Icon{Type: "emoji", Emoji: &emoji}, nil)
+
+    require.NoError(t, err)
+    assert.NotContains(t, body, "properties")
+    assert.NotContains(t, body, "cover")
+  })
+
+  t.Run("skips blocks that are not pages", func(t *testing.T) {
+    mux := http.NewServeMux()
+    mux.HandleFunc("GET /v1/blocks/block-id", func(w http.ResponseWriter, r *http.Request) {
+      _, _ = io.WriteString(w, `{"object":"block","id":"block-id","type":"toggle","toggle":{"rich_text":[]}}`)
+    })
+    mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+      t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+    })
+    notion := newTestNotion(t, mux)
+
+    err := notion.UpdatePage(t.Context(), "block-id", "Design", nil, nil)
+
+    assert.NoError(t, err)
+  })
+
+  t.Run("returns notion api errors", func(t *testing.T) {
+    notion := newTestNotion(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+      w.WriteHeader(http.StatusNotFound)
+      _, _ = io.WriteString(w, `{"object":"error","status":404,"code":"object_not_found","message":"block not found"}`)
+    }))
+
+    err := notion.UpdatePage(t.Context(), "missing-id", "Design", nil, nil)
+
+    assert.ErrorContains(t, err, "block not found")
+  })
+}
+
+// handlePageBlock ... Serve the block of a page, which the Notion API returns as a child_page block
+func handlePageBlock(mux *http.ServeMux, pageID string) {
+  mux.HandleFunc("GET /v1/blocks/"+pageID, func(w http.ResponseWriter, r *http.Request) {
+    _, _ = io.WriteString(w, `{"object":"block","id":"`+pageID+`","type":"child_page","child_page":{"title":""}}`)
+  })
+}
+
+func TestCreatePage(t *testing.T) {
+  t.Run("creates a child page", func(t *testing.T) {
+    var body map[string]any
+    mux := http.NewServeMux()
+    mux.HandleFunc("POST /v1/pages", func(w http.ResponseWriter, r *http.Request) {
+      require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+      _, _ = io.WriteString(w, `{"object":"page","id":"page-id","url":"https://www.notion.so/page-id"}`)
+    })
+    notion := newTestNotion(t, mux)
+
+    page, err := notion.CreatePage(t.Context(), "parent-id", "Design", nil, nil)
+
+    require.NoError(t, err)
+    assert.Equal(t, notionapi.ObjectID("page-id"), page.ID)
+    assert.Equal(t, "https://www.notion.so/page-id", page.URL)
+    assert.Equal(t, "parent-id", body["parent"].(map[string]any)["page_id"])
+    title := body["properties"].(map[string]any)["title"].(map[string]any)["title"].([]any)
+    assert.Equal(t, "Design", title[0].(map[string]any)["text"].(map[string]any)["content"])
+  })
+}
+
+func TestInsertBlocks(t *testing.T) {
+  t.Run("appends blocks in batches", func(t *testing.T) {
+    var sizes []int
+    mux := http.NewServeMux()
+    mux.HandleFunc("PATCH /v1/blocks/page-id/children", func(w http.ResponseWriter, r *http.Request) {
+      var body struct {
+        Children []json.RawMessage `json:"children"`
+      }
+      require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+      sizes = append(sizes, len(body.Children))
+      _, _ = io.WriteString(w, `{"object":"list","results":[]}`)
+    })
+    notion := newTestNotion(t, mux)
+
+    blocks := make([]notionapi.Block, 150)
+    for i := range blocks {
+      blocks[i] = newTableOfContentsBlock()
+    }
+    err := notion.InsertBlocks(t.Context(), "page-id", blocks)
+
+    require.NoError(t, err)
+    assert.Equal(t, []int{100, 50}, sizes)
+  })
+
+  t.Run("appends deeply nested children to the created blocks", func(t *testing.T) {
+    requests := map[string][]int{}
+    created := 0
+    mux := http.NewServeMux()
+    mux.HandleFunc("PATCH /v1/blocks/{id}/children", func(w http.ResponseWriter, r *http.Request) {
+      var body struct {
+        Children []map[string]json.RawMessage `json:"children"`
+      }
+      require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+      results := make([]map[string]any, len(body.Children))
+      for i, child := range body.Children {
+        var blockType string
+        require.NoError(t, json.Unmarshal(child["type"], &blockType))
+        created++
+        results[i] = map[string]any{"object": "block", "id": fmt.Sprintf("block-%d", created), "type": blockType}
+        requests[r.PathValue("id")] = append(requests[r.PathValue("id")], nestedDepth(t, child))
+      }
+      require.NoError(t, json.NewEncoder(w).Encode(map[string]any{"object": "list", "results": results}))
+    })
+    notion := newTestNotion(t, mux)
+
+    item := func(children ...notionapi.Block) notionapi.Block {
+      return &notionapi.BulletedListItemBlock{
+        BasicBlock:       notionapi.BasicBlock{Object: notionapi.ObjectTypeBlock, Type: notionapi.BlockTypeBulletedListItem},
+        BulletedListItem: notionapi.ListItem{RichText: chunk.RichText("item", nil), Children: children},
+      }
+    }
+    rows := make([]notionapi.Block, 150)
+    for i := range rows {
+      rows[i] = &notionapi.TableRowBlock{
+        BasicBlock: notionapi.BasicBlock{Object: notionapi.ObjectTypeBlock, Type: notionapi.BlockTypeTableRowBlock},
+        TableRow:   notionapi.TableRow{Cells: [][]notionapi.RichText{chunk.RichText("cell", nil)}},
+      }
+    }
+    table := &notionapi.TableBlock{
+      BasicBlock: notionapi.BasicBlock{Object: notionapi.ObjectTypeBlock, Type: notionapi.BlockTypeTableBlock},
+      Table:      notionapi.Table{TableWidth: 1, Children: rows},
+    }
+
+    err := notion.InsertBlocks(t.Context(), "page-id", []notionapi.Block{item(item(item(item()))), table})
+
+    require.NoError(t, err)
+    assert.Equal(t, map[string][]int{
+      // The first item is sent without its children, and the table with its first 100 rows
+      "page-id": {1, 2},
+      // The second level item can't carry its grandchild, so it is deferred again
+      "block-1": {1},
+      "block-3": {2},
+      "block-2": slices.Repeat([]int{1}, 50),
+    }, requests)
+  })
+}
+
+// nestedDepth ... Number of block levels in a JSON block, counting the block itself
+func nestedDepth(t *testing.T, block map[string]json.RawMessage) int {
+  t.Helper()
+
+  var blockType string
+  require.NoError(t, json.Unmarshal(block["type"], &blockType))
+  var content struct {
+    Children []map[string]json.RawMessage `json:"children"`
+  }
+  require.NoError(t, json.Unmarshal(block[blockType], &content))
+
+  depth := 0
+  for _, child := range content.Children {
+    depth = max(depth, nestedDepth(t, child))
+  }
+  return depth + 1
+}